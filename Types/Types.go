@@ -2,6 +2,7 @@ package Types
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/n8downs/even_challenge/money"
@@ -17,16 +18,23 @@ const (
 	Weekly
 	BiWeekly
 	OneTime
+	Recurring
+	Quarterly
+	SemiAnnually
+	Annually
+	EveryNMonths
+	EveryNDays
 )
 
-// Account ...
-type Account int
+// Account names an account. External stands for everything outside the
+// plan, such as employers, landlords and stores.
+type Account string
 
 // test
 const (
-	External Account = iota
-	Checking
-	Savings
+	External Account = "External"
+	Checking Account = "Checking"
+	Savings  Account = "Savings"
 )
 
 func (p Period) String() string {
@@ -41,11 +49,26 @@ func (p Period) String() string {
 		return "BiWeekly"
 	case OneTime:
 		return "OneTime"
+	case Recurring:
+		return "Recurring"
+	case Quarterly:
+		return "Quarterly"
+	case SemiAnnually:
+		return "SemiAnnually"
+	case Annually:
+		return "Annually"
+	case EveryNMonths:
+		return "EveryNMonths"
+	case EveryNDays:
+		return "EveryNDays"
 	default:
 		return "???"
 	}
 }
 
+// LastDayOfMonth is the Schedule Date for the last day of each month.
+const LastDayOfMonth = -1
+
 // DateFormat
 const (
 	DateFormat = "2006.01.02"
@@ -53,16 +76,37 @@ const (
 
 // Income ...
 type Income struct {
-	Name     string
-	Amount   money.Money
-	Schedule Schedule
+	Name      string
+	Amount    money.Money
+	Schedule  Schedule
+	Variation Variation
+	// Hourly, when set, works out the paydays and the pay from shifts worked
+	// in place of Schedule and Amount. Variation then applies to its Rate.
+	Hourly Hourly
+	// Range, when set, bounds what each paycheck might actually be. Plans
+	// report the ideal spending at both ends, and conservative ones budget
+	// on the minimum.
+	Range *Range
+	// Account is where the income is deposited. When empty it goes to the
+	// plan's spending account.
+	Account Account
+}
+
+// Range ...
+type Range struct {
+	Min money.Money
+	Max money.Money
 }
 
 // Expense ...
 type Expense struct {
-	Name     string
-	Amount   money.Money
-	Schedule Schedule
+	Name      string
+	Amount    money.Money
+	Schedule  Schedule
+	Variation Variation
+	// Account is the account the expense is paid from. When empty it is the
+	// plan's spending account.
+	Account Account
 }
 
 // Transaction ...
@@ -75,100 +119,288 @@ type Transaction struct {
 }
 
 func (t Transaction) String() string {
-	return fmt.Sprintf("%10s | %-40s | %15s", t.Date.Format(DateFormat), t.Memo, t.Delta.String())
+	return t.Format(money.Formatter{})
+}
+
+// Format ...
+func (t Transaction) Format(f money.Formatter) string {
+	return fmt.Sprintf("%10s | %-40s | %15s", t.Date.Format(DateFormat), t.Memo, f.Format(t.Delta))
 }
 
 // Schedule ...
 type Schedule struct {
 	Period  Period
 	Weekday time.Weekday
-	Date    int
-	Time    time.Time
+	// Date is the day of the month. Days past the end of a short month land
+	// on its last day, and negative days count back from the end as in RRULE
+	// BYMONTHDAY, so LastDayOfMonth (-1) is the last day and -3 the third to
+	// last.
+	Date int
+	// Days are the days of the month a BiMonthly schedule lands on, read like
+	// Date. They default to the 1st and 15th.
+	Days []int
+	Time time.Time
+	// RRule is an RFC 5545 recurrence rule used by Recurring schedules, such
+	// as "FREQ=MONTHLY;BYDAY=-1FR". Its DTSTART is the Anchor, Time or Start.
+	// Rules whose dates depend on DTSTART, such as "FREQ=WEEKLY;INTERVAL=3"
+	// or any with a COUNT, need one of those to be set.
+	RRule string
+	// Anchor is a known real occurrance. Weekly and BiWeekly schedules, and
+	// Recurring rules in place of Time, are phased from it so the dates don't
+	// depend on the window asked about. Its weekday overrides Weekday.
+	Anchor time.Time
+	// Interval is the N in EveryNMonths and EveryNDays. Intervals above 1
+	// are counted from the Anchor, which they need.
+	Interval int
+
+	// Adjustment moves occurrances that land on a non-business day, as
+	// decided by Calendar (Weekends when nil).
+	Adjustment Adjustment
+	Calendar   *Calendar
+
+	// Start and End bound the schedule, inclusive; zero means unbounded.
+	// Count, when positive, stops it after that many occurrances counted
+	// from Start, or else the Anchor, and needs one of them.
+	Start time.Time
+	End   time.Time
+	Count int
+	// Except skips occurrances on these dates. Overrides move or change the
+	// amount of single occurrances; both refer to the date before any
+	// Adjustment.
+	Except    []time.Time
+	Overrides []Override
+
+	// Location is the zone of the dates FindRealOccurrances returns. When nil
+	// they are in the zone of the window asked about.
+	Location *time.Location
 }
 
-// FindRealOccurrances ...
-func (s Schedule) FindRealOccurrances(from, to time.Time) (occurrances []time.Time) {
+// Override changes one occurrance. A zero MoveTo leaves it on its date and a
+// zero Amount leaves the amount alone; use Except to skip it.
+type Override struct {
+	Date   time.Time
+	MoveTo time.Time
+	Amount money.Money
+}
+
+// Validate ...
+func (s Schedule) Validate() error {
+	switch s.Period {
+	case Recurring:
+		rule, err := ParseRRule(s.RRule)
+		if err != nil {
+			return err
+		}
+		if rule.PhasedByStart() && s.dtstart().IsZero() {
+			return fmt.Errorf("RRULE %q needs a Time, Anchor or Start to count from", s.RRule)
+		}
+	case EveryNMonths, EveryNDays:
+		if s.Interval <= 0 {
+			return fmt.Errorf("%s schedule needs a positive Interval, got %d", s.Period, s.Interval)
+		}
+		if s.Interval > 1 && s.Anchor.IsZero() {
+			return fmt.Errorf("%s schedule every %d needs an Anchor to count from", s.Period, s.Interval)
+		}
+	}
+	for _, day := range append([]int{s.Date}, s.Days...) {
+		if day < -31 || day > 31 {
+			return fmt.Errorf("day of month %d out of range", day)
+		}
+	}
+	for _, day := range s.Days {
+		if day == 0 {
+			return fmt.Errorf("day of month 0 in Days")
+		}
+	}
+	if !s.Start.IsZero() && !s.End.IsZero() && s.End.Before(s.Start) {
+		return fmt.Errorf("schedule ends on %s before it starts on %s", s.End.Format(DateFormat), s.Start.Format(DateFormat))
+	}
+	if s.Count < 0 {
+		return fmt.Errorf("negative Count %d", s.Count)
+	}
+	if s.Count > 0 && s.Start.IsZero() && s.Anchor.IsZero() {
+		return fmt.Errorf("Count %d needs a Start or Anchor to count from", s.Count)
+	}
+	for _, o := range s.Overrides {
+		if o.Date.IsZero() {
+			return fmt.Errorf("override without a Date")
+		}
+	}
+	return nil
+}
+
+// FindRealOccurrances returns civil dates, at midnight in the schedule's
+// Location.
+func (s Schedule) FindRealOccurrances(from, to time.Time) []time.Time {
+	loc := s.location(from)
+	occurrances := s.inUTC().findRealOccurrances(CivilDate(from, time.UTC), CivilDate(to, time.UTC))
+	for i, date := range occurrances {
+		occurrances[i] = CivilDate(date, loc)
+	}
+	return occurrances
+}
+
+// location is where occurrance dates are returned: Location, or else from's.
+func (s Schedule) location(from time.Time) *time.Location {
+	if s.Location != nil {
+		return s.Location
+	}
+	return from.Location()
+}
+
+// inUTC moves every date on the schedule to its civil date in UTC.
+func (s Schedule) inUTC() Schedule {
+	civil := func(t time.Time) time.Time {
+		if t.IsZero() {
+			return t
+		}
+		return CivilDate(t, time.UTC)
+	}
+	s.Time, s.Anchor, s.Start, s.End = civil(s.Time), civil(s.Anchor), civil(s.Start), civil(s.End)
+	except := make([]time.Time, len(s.Except))
+	for i, date := range s.Except {
+		except[i] = civil(date)
+	}
+	overrides := make([]Override, len(s.Overrides))
+	for i, o := range s.Overrides {
+		overrides[i] = Override{civil(o.Date), civil(o.MoveTo), o.Amount}
+	}
+	s.Except, s.Overrides = except, overrides
+	return s
+}
+
+func (s Schedule) findRealOccurrances(from, to time.Time) (occurrances []time.Time) {
+	calendar := Weekends
+	if s.Calendar != nil {
+		calendar = *s.Calendar
+	}
+
+	// Adjusting can pull in dates from just outside the window. The slack is
+	// a whole number of weeks and fortnights so Weekly and BiWeekly stay in
+	// phase.
+	slack := 0
+	if s.Adjustment != NoAdjustment {
+		slack = maxAdjustmentDays
+	}
+	for _, date := range s.findBoundedOccurrances(from.AddDate(0, 0, -slack), to.AddDate(0, 0, slack)) {
+		if s.isExcepted(date) {
+			continue
+		}
+		if o := s.override(date); o != nil && !o.MoveTo.IsZero() {
+			continue
+		}
+		adjusted := s.Adjustment.Adjust(date, calendar)
+		if adjusted.Before(from) || adjusted.After(to) {
+			continue
+		}
+		occurrances = append(occurrances, adjusted)
+	}
+
+	for _, o := range s.Overrides {
+		if o.MoveTo.IsZero() || o.MoveTo.Before(from) || o.MoveTo.After(to) || s.isExcepted(o.Date) {
+			continue
+		}
+		if len(s.findBoundedOccurrances(o.Date, o.Date)) > 0 {
+			occurrances = append(occurrances, o.MoveTo)
+		}
+	}
+
+	// Occurrances adjusted onto the same business day are each still due, so
+	// a date can appear more than once.
+	sort.SliceStable(occurrances, func(i, j int) bool { return occurrances[i].Before(occurrances[j]) })
+	return occurrances
+}
+
+// findBoundedOccurrances applies Start, End and Count to the unadjusted
+// occurrances. Count is counted from Start, or the Anchor, so it doesn't
+// depend on the window; a counted schedule has nothing before that.
+func (s Schedule) findBoundedOccurrances(from, to time.Time) []time.Time {
+	if !s.Start.IsZero() && from.Before(s.Start) {
+		from = s.Start
+	}
+	if !s.End.IsZero() && to.After(s.End) {
+		to = s.End
+	}
+	if from.After(to) {
+		return nil
+	}
+	if s.Count <= 0 {
+		return s.findUnadjustedOccurrances(from, to)
+	}
+
+	origin := s.Start
+	if origin.IsZero() {
+		origin = s.Anchor
+	}
+	if origin.IsZero() || origin.After(to) {
+		return nil
+	}
+	var occurrances []time.Time
+	for i, date := range s.findUnadjustedOccurrances(origin, to) {
+		if i >= s.Count {
+			break
+		}
+		if !date.Before(from) {
+			occurrances = append(occurrances, date)
+		}
+	}
+	return occurrances
+}
+
+func (s Schedule) isExcepted(date time.Time) bool {
+	for _, except := range s.Except {
+		if sameDay(except, date) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Schedule) override(date time.Time) *Override {
+	for i := range s.Overrides {
+		if sameDay(s.Overrides[i].Date, date) {
+			return &s.Overrides[i]
+		}
+	}
+	return nil
+}
+
+// AmountOn returns the amount due on an occurrance, which is amount unless an
+// Override changes it.
+func (s Schedule) AmountOn(date time.Time, amount money.Money) money.Money {
+	calendar := Weekends
+	if s.Calendar != nil {
+		calendar = *s.Calendar
+	}
+	for _, o := range s.Overrides {
+		on := o.MoveTo
+		if on.IsZero() {
+			on = s.Adjustment.Adjust(o.Date, calendar)
+		}
+		if sameDay(on, date) && !o.Amount.EqualTo(money.Money{}) {
+			return o.Amount
+		}
+	}
+	return amount
+}
+
+func (s Schedule) findUnadjustedOccurrances(from, to time.Time) (occurrances []time.Time) {
 	switch s.Period {
 	case Monthly:
 		{
-			fromYear, fromMonth, _ := from.Date()
-			occ := time.Date(fromYear, fromMonth, s.Date, 0, 0, 0, 0, time.UTC)
-			if occ.Before(from) {
-				occ = occ.AddDate(0, 1, 0)
-			}
-			for {
-				if occ.After(to) {
-					break
-				}
-				occurrances = append(occurrances, occ)
-				occ = occ.AddDate(0, 1, 0)
-			}
+			occurrances = s.everyNMonths(1, from, to)
 		}
 	case BiMonthly:
 		{
-			// TODO: handle the case where the 1st or 15th falls on a weekend/holiday
-			// (payment should happen on the Friday before in that case)
-			fromYear, fromMonth, _ := from.Date()
-			occ1 := time.Date(fromYear, fromMonth, 1, 0, 0, 0, 0, time.UTC)
-			occ15 := time.Date(fromYear, fromMonth, 15, 0, 0, 0, 0, time.UTC)
-			if occ1.Before(from) {
-				occ1.AddDate(0, 1, 0)
-			}
-			if occ15.Before(from) {
-				occ15.AddDate(0, 1, 0)
-			}
-
-			for {
-				if occ1.After(to) {
-					break
-				}
-				occurrances = append(occurrances, occ1)
-				occ1 = occ1.AddDate(0, 1, 0)
-
-				if occ15.After(to) {
-					break
-				}
-				occurrances = append(occurrances, occ15)
-				occ15 = occ15.AddDate(0, 1, 0)
-			}
+			occurrances = s.semiMonthly(from, to)
 		}
 	case Weekly:
 		{
-			occ := from
-			for {
-				if occ.Weekday() == s.Weekday {
-					break
-				}
-				occ = occ.AddDate(0, 0, 1)
-			}
-
-			for {
-				if occ.After(to) {
-					break
-				}
-				occurrances = append(occurrances, occ)
-				occ = occ.AddDate(0, 0, 7)
-			}
+			occurrances = s.everyNDays(7, from, to)
 		}
 	case BiWeekly:
 		{
-			// TODO: probably want a way to specify that the next occurance will not
-			// be the first we come across, but rather the second from the start
-			occ := from
-			for {
-				if occ.Weekday() == s.Weekday {
-					break
-				}
-				occ = occ.AddDate(0, 0, 1)
-			}
-
-			for {
-				if occ.After(to) {
-					break
-				}
-				occurrances = append(occurrances, occ)
-				occ = occ.AddDate(0, 0, 14)
-			}
+			occurrances = s.everyNDays(14, from, to)
 		}
 	case OneTime:
 		{
@@ -176,13 +408,233 @@ func (s Schedule) FindRealOccurrances(from, to time.Time) (occurrances []time.Ti
 				occurrances = append(occurrances, s.Time)
 			}
 		}
+	case Recurring:
+		{
+			rule, err := ParseRRule(s.RRule)
+			if err != nil {
+				return nil
+			}
+			dtstart := s.dtstart()
+			if dtstart.IsZero() {
+				if rule.PhasedByStart() {
+					return nil
+				}
+				dtstart = from
+			}
+			occurrances = rule.Between(dtstart, from, to)
+		}
+	case Quarterly:
+		{
+			occurrances = s.everyNMonths(3, from, to)
+		}
+	case SemiAnnually:
+		{
+			occurrances = s.everyNMonths(6, from, to)
+		}
+	case Annually:
+		{
+			occurrances = s.everyNMonths(12, from, to)
+		}
+	case EveryNMonths:
+		{
+			if s.Interval == 1 || (s.Interval > 1 && !s.Anchor.IsZero()) {
+				occurrances = s.everyNMonths(s.Interval, from, to)
+			}
+		}
+	case EveryNDays:
+		{
+			switch {
+			case s.Interval == 1 && s.Anchor.IsZero():
+				s.Anchor = from
+				occurrances = s.everyNDays(1, from, to)
+			case s.Interval > 0 && !s.Anchor.IsZero():
+				occurrances = s.everyNDays(s.Interval, from, to)
+			}
+		}
+	}
+
+	return
+}
+
+// everyNDays steps n days at a time from the first occurrance on or after
+// from. Without an Anchor that is the first day matching Weekday.
+func (s Schedule) everyNDays(n int, from, to time.Time) (occurrances []time.Time) {
+	occ := from
+	if s.Anchor.IsZero() {
+		for occ.Weekday() != s.Weekday {
+			occ = occ.AddDate(0, 0, 1)
+		}
+	} else {
+		steps := DaysBetween(s.Anchor, from) / n
+		occ = s.Anchor.AddDate(0, 0, steps*n)
+		for occ.Before(from) {
+			occ = occ.AddDate(0, 0, n)
+		}
+	}
+
+	for !occ.After(to) {
+		occurrances = append(occurrances, occ)
+		occ = occ.AddDate(0, 0, n)
+	}
+	return
+}
+
+// everyNMonths lands on day Date of every nth month, counting from the
+// Anchor's month or, without one, from January so that quarters and halves
+// are the calendar's. Date defaults to the Anchor's day, or the 1st. Each
+// month is worked out on its own so a clamped day doesn't carry into the
+// next.
+func (s Schedule) everyNMonths(n int, from, to time.Time) (occurrances []time.Time) {
+	baseMonth, day := 0, s.Date
+	if !s.Anchor.IsZero() {
+		baseMonth = s.Anchor.Year()*12 + int(s.Anchor.Month()) - 1
+		if day == 0 {
+			day = s.Anchor.Day()
+		}
+	}
+	if day == 0 {
+		day = 1
+	}
+
+	fromMonth := from.Year()*12 + int(from.Month()) - 1
+	k := (fromMonth - baseMonth) / n
+	if fromMonth < baseMonth {
+		k--
+	}
+	for {
+		month := baseMonth + k*n
+		year, m := month/12, time.Month(month%12+1)
+		occ := dayOfMonth(year, m, day)
+		if occ.After(to) {
+			break
+		}
+		if !occ.Before(from) {
+			occurrances = append(occurrances, occ)
+		}
+		k++
 	}
+	return
+}
 
+// semiMonthly lands on each of Days in every month from from's month to to's.
+func (s Schedule) semiMonthly(from, to time.Time) (occurrances []time.Time) {
+	days := s.Days
+	if len(days) == 0 {
+		days = []int{1, 15}
+	}
+	year, month, _ := from.Date()
+	for first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC); !first.After(to); first = first.AddDate(0, 1, 0) {
+		var dates []time.Time
+		for _, day := range days {
+			dates = append(dates, dayOfMonth(first.Year(), first.Month(), day))
+		}
+		sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+		for _, date := range dates {
+			if date.Before(from) || date.After(to) {
+				continue
+			}
+			if n := len(occurrances); n > 0 && date.Equal(occurrances[n-1]) {
+				continue
+			}
+			occurrances = append(occurrances, date)
+		}
+	}
 	return
 }
 
+// dayOfMonth resolves a Schedule Date within one month.
+func dayOfMonth(year int, month time.Month, day int) time.Time {
+	last := daysIn(year, month)
+	if day < 0 {
+		day = last + day + 1
+	}
+	if day < 1 {
+		day = 1
+	}
+	if day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// dtstart is where a Recurring rule starts counting from, or zero when the
+// schedule doesn't say. Only rules that aren't PhasedByStart can do without
+// one; they give the same dates starting from any day.
+func (s Schedule) dtstart() time.Time {
+	if !s.Anchor.IsZero() {
+		return s.Anchor
+	}
+	if !s.Time.IsZero() {
+		return s.Time
+	}
+	return s.Start
+}
+
+// DaysBetween counts calendar days from a to b, negative when b is earlier.
+// Days are read as written in each time's own zone, so a 23 or 25 hour day
+// across a DST change still counts as one.
+func DaysBetween(a, b time.Time) int {
+	return int(CivilDate(b, time.UTC).Sub(CivilDate(a, time.UTC)).Hours() / 24)
+}
+
+// CivilDate is midnight in loc on the calendar day t is written as, ignoring
+// t's clock and zone. Schedules and plans key everything by civil dates so
+// that dates built in different zones still match.
+func CivilDate(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// Validate ...
+func (i Income) Validate() error {
+	if i.Range != nil && i.Range.Min.GreaterThan(i.Range.Max) {
+		return fmt.Errorf("income range minimum %s is above its maximum %s", i.Range.Min, i.Range.Max)
+	}
+	if !i.Hourly.IsZero() {
+		return i.Hourly.Validate()
+	}
+	return i.Schedule.Validate()
+}
+
+// FindRealOccurrances ...
+func (i Income) FindRealOccurrances(from, to time.Time) []time.Time {
+	if !i.Hourly.IsZero() {
+		return i.Hourly.Paydays(from, to)
+	}
+	return i.Schedule.FindRealOccurrances(from, to)
+}
+
+// AmountOn ...
+func (i Income) AmountOn(date time.Time) money.Money {
+	if !i.Hourly.IsZero() {
+		return i.Hourly.PayOn(date, i.Variation.AmountOn(date, i.Hourly.Rate))
+	}
+	return i.Schedule.AmountOn(date, i.Variation.AmountOn(date, i.Amount))
+}
+
+// RangeOn returns the least, expected and most a paycheck on date might be.
+// Without a Range all three are the expected amount.
+func (i Income) RangeOn(date time.Time) (min, expected, max money.Money) {
+	expected = i.AmountOn(date)
+	if i.Range == nil {
+		return expected, expected, expected
+	}
+	return money.Min(i.Range.Min, expected), expected, money.Max(i.Range.Max, expected)
+}
+
+// AmountOn ...
+func (e Expense) AmountOn(date time.Time) money.Money {
+	return e.Schedule.AmountOn(date, e.Variation.AmountOn(date, e.Amount))
+}
+
 // FindVirtualOccurrances ...
 func (e Expense) FindVirtualOccurrances(from, to time.Time) map[time.Time]money.Money {
+	return e.FindVirtualOccurrancesWith(from, to, e.AmountOn)
+}
+
+// FindVirtualOccurrancesWith is FindVirtualOccurrances with the amount due on
+// each real occurrance supplied by amountOn.
+func (e Expense) FindVirtualOccurrancesWith(from, to time.Time, amountOn func(date time.Time) money.Money) map[time.Time]money.Money {
 	occurrances := map[time.Time]money.Money{}
 	switch e.Schedule.Period {
 	/*
@@ -196,7 +648,7 @@ func (e Expense) FindVirtualOccurrances(from, to time.Time) map[time.Time]money.
 				currentDate := from
 				for _, realDate := range realDates {
 					dates := v.FindRealOccurrances(currentDate, realDate)
-					amounts := e.Amount.Divide(int64(len(dates)))
+					amounts := amountOn(realDate).Divide(int64(len(dates)))
 					for i := 0; i < len(dates); i++ {
 						occurrances[dates[i]] = amounts[i]
 					}
@@ -204,27 +656,54 @@ func (e Expense) FindVirtualOccurrances(from, to time.Time) map[time.Time]money.
 				}
 			}
 	*/
-	case OneTime:
+	case OneTime, Quarterly, SemiAnnually, Annually, EveryNMonths, EveryNDays:
 		{
-			realDates := e.Schedule.FindRealOccurrances(from, to)
-			if len(realDates) == 0 {
-				return occurrances
-			}
-			v := Schedule{
-				Period: Weekly,
-			}
-			dates := v.FindRealOccurrances(from, e.Schedule.Time)
-			amounts := e.Amount.Divide(int64(len(dates)))
-			for i := 0; i < len(dates); i++ {
-				occurrances[dates[i]] = amounts[i]
+			// Set each payment aside weekly over the days leading up to it.
+			start := from
+			for _, realDate := range e.Schedule.FindRealOccurrances(from, to) {
+				for date, amount := range spreadWeekly(start, realDate, amountOn(realDate), e.Schedule.location(from)) {
+					occurrances[date] = occurrances[date].Add(amount)
+				}
+				start = realDate.AddDate(0, 0, 1)
 			}
 		}
 	default:
 		{
 			for _, date := range e.Schedule.FindRealOccurrances(from, to) {
-				occurrances[date] = e.Amount
+				occurrances[date] = occurrances[date].Add(amountOn(date))
 			}
 		}
 	}
 	return occurrances
 }
+
+// spreadWeekly splits amount across the Sundays from start through due, or
+// leaves it all on due when there are none.
+func spreadWeekly(start, due time.Time, amount money.Money, loc *time.Location) map[time.Time]money.Money {
+	v := Schedule{
+		Period:   Weekly,
+		Location: loc,
+	}
+	dates := v.FindRealOccurrances(start, due)
+	if len(dates) == 0 {
+		return map[time.Time]money.Money{due: amount}
+	}
+	occurrances := map[time.Time]money.Money{}
+	amounts := amount.Allocate(daysCovered(start, dates)...)
+	for i := 0; i < len(dates); i++ {
+		occurrances[dates[i]] = amounts[i]
+	}
+	return occurrances
+}
+
+// daysCovered weights each virtual occurrance by the number of days since the
+// one before it, so a short first week sets aside less.
+func daysCovered(from time.Time, dates []time.Time) []int {
+	weights := make([]int, len(dates))
+	previous := from.AddDate(0, 0, -1)
+	for i, date := range dates {
+		weights[i] = DaysBetween(previous, date)
+		previous = date
+	}
+	return weights
+}
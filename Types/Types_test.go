@@ -0,0 +1,434 @@
+package Types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/n8downs/even_challenge/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(s string) time.Time {
+	d, _ := time.Parse(DateFormat, s)
+	return d
+}
+
+func TestOneTimeVirtualOccurrancesAreWeightedByDays(t *testing.T) {
+	expense := Expense{
+		Amount:   money.New(100.),
+		Name:     "Vacation",
+		Schedule: Schedule{Period: OneTime, Time: date("2015.08.16")},
+	}
+
+	occurrances := expense.FindVirtualOccurrances(date("2015.08.01"), date("2015.08.31"))
+	assert.Equal(t, map[time.Time]money.Money{
+		date("2015.08.02"): money.New(12.50),
+		date("2015.08.09"): money.New(43.75),
+		date("2015.08.16"): money.New(43.75),
+	}, occurrances)
+}
+
+func TestAnchoredBiWeeklyIgnoresWindow(t *testing.T) {
+	s := Schedule{Period: BiWeekly, Anchor: date("2015.08.13")}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.13"), date("2015.08.27")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2015.08.31")),
+	)
+	assert.Equal(t,
+		[]time.Time{date("2015.08.13"), date("2015.08.27")},
+		s.FindRealOccurrances(date("2015.08.07"), date("2015.08.31")),
+	)
+	assert.Equal(t,
+		[]time.Time{date("2015.07.30"), date("2015.08.13")},
+		s.FindRealOccurrances(date("2015.07.20"), date("2015.08.26")),
+	)
+}
+
+func TestAnchorOverridesWeekday(t *testing.T) {
+	s := Schedule{Period: Weekly, Weekday: time.Monday, Anchor: date("2015.08.06")}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.06"), date("2015.08.13")},
+		s.FindRealOccurrances(date("2015.08.04"), date("2015.08.15")),
+	)
+}
+
+func TestAnchoredRecurringRule(t *testing.T) {
+	s := Schedule{Period: Recurring, RRule: "FREQ=WEEKLY;INTERVAL=2", Anchor: date("2015.08.13")}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.27"), date("2015.09.10")},
+		s.FindRealOccurrances(date("2015.08.20"), date("2015.09.15")),
+	)
+}
+
+func TestQuarterlyFromAnchor(t *testing.T) {
+	s := Schedule{Period: Quarterly, Anchor: date("2015.02.15")}
+	assert.Equal(t,
+		[]time.Time{date("2015.05.15"), date("2015.08.15"), date("2015.11.15")},
+		s.FindRealOccurrances(date("2015.04.01"), date("2015.12.31")),
+	)
+}
+
+func TestAnnuallyAndSemiAnnually(t *testing.T) {
+	s := Schedule{Period: Annually, Anchor: date("2014.10.01")}
+	assert.Equal(t,
+		[]time.Time{date("2015.10.01"), date("2016.10.01")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2016.12.31")),
+	)
+
+	s = Schedule{Period: SemiAnnually, Date: 20, Anchor: date("2015.01.01")}
+	assert.Equal(t,
+		[]time.Time{date("2015.07.20"), date("2016.01.20")},
+		s.FindRealOccurrances(date("2015.02.01"), date("2016.02.01")),
+	)
+}
+
+func TestEveryNMonthsClampsToMonthEnd(t *testing.T) {
+	s := Schedule{Period: EveryNMonths, Interval: 2, Date: 31, Anchor: date("2015.08.31")}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.31"), date("2015.10.31"), date("2015.12.31"), date("2016.02.29")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2016.03.31")),
+	)
+}
+
+func TestEveryNDays(t *testing.T) {
+	s := Schedule{Period: EveryNDays, Interval: 10, Anchor: date("2015.07.28")}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.07"), date("2015.08.17"), date("2015.08.27")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2015.08.31")),
+	)
+
+	// Without an Anchor the dates would depend on the window asked about.
+	s = Schedule{Period: EveryNDays, Interval: 10}
+	assert.Error(t, s.Validate())
+	assert.Empty(t, s.FindRealOccurrances(date("2015.08.01"), date("2015.08.20")))
+
+	s = Schedule{Period: EveryNDays, Interval: 1}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.01"), date("2015.08.02"), date("2015.08.03")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2015.08.03")),
+	)
+}
+
+func TestUnanchoredQuarterlyFollowsTheCalendar(t *testing.T) {
+	s := Schedule{Period: Quarterly, Date: 15}
+	assert.Equal(t,
+		[]time.Time{date("2015.10.15"), date("2016.01.15")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2016.02.01")),
+	)
+	assert.Equal(t,
+		[]time.Time{date("2015.10.15"), date("2016.01.15")},
+		s.FindRealOccurrances(date("2015.09.20"), date("2016.02.01")),
+	)
+}
+
+func TestIntervalIsRequired(t *testing.T) {
+	assert.Error(t, Schedule{Period: EveryNMonths}.Validate())
+	assert.Error(t, Schedule{Period: EveryNDays, Interval: -1}.Validate())
+	assert.Error(t, Schedule{Period: EveryNMonths, Interval: 2}.Validate())
+	assert.Nil(t, Schedule{Period: EveryNMonths, Interval: 1}.Validate())
+	assert.Nil(t, Schedule{Period: EveryNDays, Interval: 3, Anchor: date("2015.08.01")}.Validate())
+}
+
+func TestAnnualVirtualOccurrancesAreSpreadWeekly(t *testing.T) {
+	expense := Expense{
+		Amount:   money.New(100.),
+		Name:     "Car Insurance",
+		Schedule: Schedule{Period: Annually, Anchor: date("2014.08.16")},
+	}
+
+	occurrances := expense.FindVirtualOccurrances(date("2015.08.01"), date("2015.08.31"))
+	assert.Equal(t, map[time.Time]money.Money{
+		date("2015.08.02"): money.New(12.50),
+		date("2015.08.09"): money.New(43.75),
+		date("2015.08.16"): money.New(43.75),
+	}, occurrances)
+}
+
+func TestVirtualOccurrancesRestartAfterEachPayment(t *testing.T) {
+	expense := Expense{
+		Amount:   money.New(70.),
+		Name:     "Property Tax",
+		Schedule: Schedule{Period: EveryNMonths, Interval: 1, Date: 9},
+	}
+
+	occurrances := expense.FindVirtualOccurrances(date("2015.08.03"), date("2015.09.30"))
+	assert.Equal(t, map[time.Time]money.Money{
+		date("2015.08.09"): money.New(70.),
+		date("2015.08.16"): money.New(17.50),
+		date("2015.08.23"): money.New(17.50),
+		date("2015.08.30"): money.New(17.50),
+		date("2015.09.06"): money.New(17.50),
+	}, occurrances)
+}
+
+func TestVirtualOccurranceWithoutASunday(t *testing.T) {
+	expense := Expense{
+		Amount:   money.New(30.),
+		Schedule: Schedule{Period: OneTime, Time: date("2015.08.05")},
+	}
+	assert.Equal(t, map[time.Time]money.Money{
+		date("2015.08.05"): money.New(30.),
+	}, expense.FindVirtualOccurrances(date("2015.08.03"), date("2015.08.31")))
+}
+
+func TestMonthlyClampsWithoutDrifting(t *testing.T) {
+	s := Schedule{Period: Monthly, Date: 31}
+	assert.Equal(t,
+		[]time.Time{date("2016.01.31"), date("2016.02.29"), date("2016.03.31"), date("2016.04.30")},
+		s.FindRealOccurrances(date("2016.01.01"), date("2016.04.30")),
+	)
+
+	s = Schedule{Period: Monthly, Date: 30}
+	occurrances := s.FindRealOccurrances(date("2015.01.01"), date("2018.12.31"))
+	assert.Equal(t, 48, len(occurrances))
+	assert.Equal(t, date("2018.12.30"), occurrances[47])
+}
+
+func TestMonthlyLastDayOfMonth(t *testing.T) {
+	s := Schedule{Period: Monthly, Date: LastDayOfMonth}
+	assert.Equal(t,
+		[]time.Time{date("2015.01.31"), date("2015.02.28"), date("2015.03.31"), date("2015.04.30")},
+		s.FindRealOccurrances(date("2015.01.01"), date("2015.04.30")),
+	)
+}
+
+func TestMonthlyCountsBackFromMonthEnd(t *testing.T) {
+	s := Schedule{Period: Monthly, Date: -3}
+	assert.Equal(t,
+		[]time.Time{date("2016.01.29"), date("2016.02.27"), date("2016.03.29")},
+		s.FindRealOccurrances(date("2016.01.01"), date("2016.03.31")),
+	)
+	assert.Equal(t,
+		[]time.Time{date("2016.02.27")},
+		s.FindRealOccurrances(date("2016.01.30"), date("2016.02.28")),
+	)
+}
+
+func TestDayOfMonthOutOfRange(t *testing.T) {
+	assert.Error(t, Schedule{Period: Monthly, Date: 32}.Validate())
+	assert.Error(t, Schedule{Period: Monthly, Date: -32}.Validate())
+	assert.Nil(t, Schedule{Period: Monthly, Date: LastDayOfMonth}.Validate())
+}
+
+func TestScheduleStartAndEnd(t *testing.T) {
+	s := Schedule{Period: Weekly, Weekday: time.Tuesday, End: date("2015.08.18")}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.04"), date("2015.08.11"), date("2015.08.18")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2015.08.31")),
+	)
+
+	s = Schedule{Period: Monthly, Date: 10, Start: date("2015.09.10")}
+	assert.Equal(t,
+		[]time.Time{date("2015.09.10"), date("2015.10.10")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2015.10.31")),
+	)
+	assert.Equal(t, 0, len(s.FindRealOccurrances(date("2015.08.01"), date("2015.08.31"))))
+}
+
+func TestScheduleCountIsCountedFromStart(t *testing.T) {
+	s := Schedule{Period: Monthly, Date: 5, Start: date("2015.07.01"), Count: 3}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.05"), date("2015.09.05")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2015.12.31")),
+	)
+
+	// Counted from the Anchor, the same three payments come back whichever
+	// window is asked about.
+	s = Schedule{Period: Weekly, Anchor: date("2015.08.03"), Count: 3}
+	assert.Equal(t, dates("2015.08.10", "2015.08.17"), s.FindRealOccurrances(date("2015.08.10"), date("2015.08.24")))
+	assert.Equal(t, dates("2015.08.03", "2015.08.10", "2015.08.17"), s.FindRealOccurrances(date("2015.07.01"), date("2015.08.24")))
+
+	s = Schedule{Period: Weekly, Weekday: time.Monday, Count: 3}
+	assert.Error(t, s.Validate())
+	assert.Empty(t, s.FindRealOccurrances(date("2015.08.01"), date("2015.08.31")))
+}
+
+func TestScheduleExceptions(t *testing.T) {
+	s := Schedule{
+		Period:  Weekly,
+		Weekday: time.Tuesday,
+		Except:  []time.Time{date("2015.08.11")},
+	}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.04"), date("2015.08.18")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2015.08.20")),
+	)
+}
+
+func TestScheduleOverrides(t *testing.T) {
+	s := Schedule{
+		Period: Monthly,
+		Date:   15,
+		Anchor: date("2015.01.15"),
+		Count:  12,
+		Overrides: []Override{
+			{Date: date("2015.09.15"), MoveTo: date("2015.10.02")},
+			{Date: date("2015.10.15"), Amount: money.New(75.)},
+			{Date: date("2015.12.15"), MoveTo: date("2016.01.05")},
+		},
+	}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.15"), date("2015.10.02"), date("2015.10.15"), date("2015.11.15")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2015.11.30")),
+	)
+	assert.Equal(t,
+		[]time.Time{date("2016.01.05")},
+		s.FindRealOccurrances(date("2015.12.01"), date("2016.02.28")),
+	)
+
+	expense := Expense{Amount: money.New(50.), Schedule: s}
+	assert.Equal(t, money.New(75.), expense.AmountOn(date("2015.10.15")))
+	assert.Equal(t, money.New(50.), expense.AmountOn(date("2015.10.02")))
+}
+
+func TestOverrideMovedOntoAnotherOccurrance(t *testing.T) {
+	expense := Expense{
+		Amount: money.New(400.),
+		Name:   "Rent",
+		Schedule: Schedule{
+			Period:    Monthly,
+			Date:      28,
+			Overrides: []Override{{Date: date("2015.08.28"), MoveTo: date("2015.09.28")}},
+		},
+	}
+	assert.Equal(t,
+		dates("2015.09.28", "2015.09.28"),
+		expense.Schedule.FindRealOccurrances(date("2015.08.01"), date("2015.09.30")),
+	)
+	occurrances := expense.FindVirtualOccurrances(date("2015.08.01"), date("2015.09.30"))
+	assert.Equal(t, map[time.Time]money.Money{date("2015.09.28"): money.New(800.)}, occurrances)
+}
+
+func TestVirtualOccurrancesHonorOverrides(t *testing.T) {
+	expense := Expense{
+		Amount: money.New(100.),
+		Schedule: Schedule{
+			Period:    OneTime,
+			Time:      date("2015.08.23"),
+			Overrides: []Override{{Date: date("2015.08.23"), MoveTo: date("2015.08.16"), Amount: money.New(80.)}},
+		},
+	}
+	occurrances := expense.FindVirtualOccurrances(date("2015.08.01"), date("2015.08.31"))
+	assert.Equal(t, map[time.Time]money.Money{
+		date("2015.08.02"): money.New(10.),
+		date("2015.08.09"): money.New(35.),
+		date("2015.08.16"): money.New(35.),
+	}, occurrances)
+}
+
+func TestInvalidBounds(t *testing.T) {
+	assert.Error(t, Schedule{Start: date("2015.08.02"), End: date("2015.08.01")}.Validate())
+	assert.Error(t, Schedule{Count: -1}.Validate())
+	assert.Error(t, Schedule{Overrides: []Override{{Amount: money.New(1.)}}}.Validate())
+}
+
+func TestIncomeRange(t *testing.T) {
+	income := Income{Amount: money.New(500.), Schedule: Schedule{Period: BiMonthly}}
+	min, expected, max := income.RangeOn(date("2015.08.15"))
+	assert.Equal(t, []money.Money{money.New(500.), money.New(500.), money.New(500.)}, []money.Money{min, expected, max})
+
+	income.Range = &Range{Min: money.New(0.), Max: money.New(650.)}
+	min, expected, max = income.RangeOn(date("2015.08.15"))
+	assert.Equal(t, []money.Money{money.New(0.), money.New(500.), money.New(650.)}, []money.Money{min, expected, max})
+
+	income.Range = &Range{Min: money.New(550.), Max: money.New(650.)}
+	min, _, _ = income.RangeOn(date("2015.08.15"))
+	assert.Equal(t, money.New(500.), min)
+
+	income.Range = &Range{Min: money.New(700.), Max: money.New(650.)}
+	assert.Error(t, income.Validate())
+}
+
+func losAngeles(t *testing.T) *time.Location {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip("no time zone data:", err)
+	}
+	return loc
+}
+
+func TestDaysBetweenAcrossDST(t *testing.T) {
+	la := losAngeles(t)
+	assert.Equal(t, 2, DaysBetween(time.Date(2015, 3, 7, 0, 0, 0, 0, la), time.Date(2015, 3, 9, 0, 0, 0, 0, la)))
+	assert.Equal(t, 2, DaysBetween(time.Date(2015, 10, 31, 0, 0, 0, 0, la), time.Date(2015, 11, 2, 0, 0, 0, 0, la)))
+	assert.Equal(t, -1, DaysBetween(time.Date(2015, 8, 2, 23, 0, 0, 0, la), date("2015.08.01")))
+}
+
+func TestOccurrancesAreCivilDatesInLocation(t *testing.T) {
+	la := losAngeles(t)
+	from := time.Date(2015, 3, 1, 0, 0, 0, 0, la)
+	to := time.Date(2015, 3, 31, 0, 0, 0, 0, la)
+
+	monthly := Schedule{Period: Monthly, Date: 15}
+	assert.Equal(t, []time.Time{time.Date(2015, 3, 15, 0, 0, 0, 0, la)}, monthly.FindRealOccurrances(from, to))
+
+	weekly := Schedule{Period: Weekly, Weekday: time.Monday}
+	occurrances := weekly.FindRealOccurrances(from, to)
+	assert.Equal(t, 5, len(occurrances))
+	for _, occurrance := range occurrances {
+		assert.Equal(t, 0, occurrance.Hour())
+		assert.Equal(t, la, occurrance.Location())
+	}
+
+	monthly.Location = time.UTC
+	assert.Equal(t, []time.Time{date("2015.03.15")}, monthly.FindRealOccurrances(from, to))
+}
+
+func TestScheduleDatesFromOtherZones(t *testing.T) {
+	la := losAngeles(t)
+	s := Schedule{
+		Period:  Weekly,
+		Weekday: time.Tuesday,
+		Start:   date("2015.08.04"),
+		Except:  []time.Time{date("2015.08.11")},
+	}
+	assert.Equal(t,
+		[]time.Time{time.Date(2015, 8, 4, 0, 0, 0, 0, la), time.Date(2015, 8, 18, 0, 0, 0, 0, la)},
+		s.FindRealOccurrances(time.Date(2015, 8, 1, 0, 0, 0, 0, la), time.Date(2015, 8, 20, 0, 0, 0, 0, la)),
+	)
+}
+
+func TestBiMonthlyRollsOverPastDays(t *testing.T) {
+	s := Schedule{Period: BiMonthly}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.15"), date("2015.09.01"), date("2015.09.15")},
+		s.FindRealOccurrances(date("2015.08.03"), date("2015.09.20")),
+	)
+	assert.Equal(t,
+		[]time.Time{date("2015.09.01")},
+		s.FindRealOccurrances(date("2015.08.16"), date("2015.09.14")),
+	)
+}
+
+func TestBiMonthlyCustomDays(t *testing.T) {
+	s := Schedule{Period: BiMonthly, Days: []int{LastDayOfMonth, 15}}
+	assert.Equal(t,
+		[]time.Time{date("2016.01.31"), date("2016.02.15"), date("2016.02.29"), date("2016.03.15")},
+		s.FindRealOccurrances(date("2016.01.20"), date("2016.03.20")),
+	)
+
+	s = Schedule{Period: BiMonthly, Days: []int{7, 22}}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.22"), date("2015.09.07")},
+		s.FindRealOccurrances(date("2015.08.08"), date("2015.09.21")),
+	)
+
+	s = Schedule{Period: BiMonthly, Days: []int{30, 31}}
+	assert.Equal(t,
+		[]time.Time{date("2015.02.28")},
+		s.FindRealOccurrances(date("2015.02.01"), date("2015.02.28")),
+	)
+}
+
+func TestBiMonthlyCustomDaysAdjusted(t *testing.T) {
+	s := Schedule{Period: BiMonthly, Days: []int{15, LastDayOfMonth}, Adjustment: Preceding}
+	assert.Equal(t,
+		[]time.Time{date("2015.08.14"), date("2015.08.31"), date("2015.09.15"), date("2015.09.30"), date("2015.10.15"), date("2015.10.30")},
+		s.FindRealOccurrances(date("2015.08.01"), date("2015.10.31")),
+	)
+}
+
+func TestInvalidDays(t *testing.T) {
+	assert.Error(t, Schedule{Period: BiMonthly, Days: []int{0, 15}}.Validate())
+	assert.Error(t, Schedule{Period: BiMonthly, Days: []int{15, 32}}.Validate())
+}
@@ -0,0 +1,164 @@
+package Types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/n8downs/even_challenge/money"
+)
+
+// AccountKind ...
+type AccountKind int
+
+// AccountKinds ...
+const (
+	CheckingAccount AccountKind = iota
+	SavingsAccount
+	CreditAccount
+	CashAccount
+	InvestmentAccount
+)
+
+func (k AccountKind) String() string {
+	switch k {
+	case CheckingAccount:
+		return "Checking"
+	case SavingsAccount:
+		return "Savings"
+	case CreditAccount:
+		return "Credit"
+	case CashAccount:
+		return "Cash"
+	case InvestmentAccount:
+		return "Investment"
+	default:
+		return "???"
+	}
+}
+
+// AccountInfo describes an account in a Registry. Opening is its balance
+// before the first day of a plan. An empty Currency means the plan's.
+type AccountInfo struct {
+	Name     Account
+	Kind     AccountKind
+	Currency money.Currency
+	Opening  money.Money
+	// Credit is how a CreditAccount bills. A credit account's balance is
+	// negative while money is owed on it.
+	Credit *CreditTerms
+}
+
+// CreditTerms are how a credit account bills. A statement closes on each
+// Statement date and its balance is due DueDays later. While any of a
+// statement is left unpaid past its due date, the balance owed accrues
+// interest at APR.
+type CreditTerms struct {
+	Statement Schedule
+	DueDays   int
+	APR       money.Rate
+	DayCount  money.DayCount
+}
+
+// Due ...
+func (c CreditTerms) Due(closing time.Time) time.Time {
+	return closing.AddDate(0, 0, c.DueDays)
+}
+
+// Validate ...
+func (c CreditTerms) Validate() error {
+	if c.DueDays < 0 {
+		return fmt.Errorf("statement due %d days before it closes", -c.DueDays)
+	}
+	return c.Statement.Validate()
+}
+
+// Registry is the set of accounts a plan moves money between, kept in the
+// order they were registered. External is always implied.
+type Registry struct {
+	accounts []AccountInfo
+}
+
+// NewRegistry ...
+func NewRegistry(accounts ...AccountInfo) (*Registry, error) {
+	r := &Registry{}
+	for _, account := range accounts {
+		if err := r.Register(account); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// DefaultRegistry holds the Checking and Savings accounts that plans used
+// before accounts could be named.
+func DefaultRegistry() *Registry {
+	return &Registry{accounts: []AccountInfo{
+		{Name: Checking, Kind: CheckingAccount},
+		{Name: Savings, Kind: SavingsAccount},
+	}}
+}
+
+// Register ...
+func (r *Registry) Register(account AccountInfo) error {
+	switch {
+	case account.Name == "":
+		return fmt.Errorf("account without a name")
+	case account.Name == External:
+		return fmt.Errorf("%s can't be registered", External)
+	case !account.Opening.Compatible(money.NewIn(0., account.Currency)):
+		return fmt.Errorf("account %s: opening balance %s: %w", account.Name, account.Opening, money.ErrCurrencyMismatch)
+	case account.Credit != nil && account.Kind != CreditAccount:
+		return fmt.Errorf("account %s: only credit accounts have credit terms", account.Name)
+	}
+	if account.Credit != nil {
+		if err := account.Credit.Validate(); err != nil {
+			return fmt.Errorf("account %s: %w", account.Name, err)
+		}
+	}
+	if _, ok := r.Lookup(account.Name); ok {
+		return fmt.Errorf("account %s registered twice", account.Name)
+	}
+	r.accounts = append(r.accounts, account)
+	return nil
+}
+
+// SetOpening sets the opening balance of a registered account.
+func (r *Registry) SetOpening(name Account, balance money.Money) error {
+	for i, account := range r.accounts {
+		if account.Name != name {
+			continue
+		}
+		if !balance.Compatible(money.NewIn(0., account.Currency)) {
+			return fmt.Errorf("account %s: opening balance %s: %w", name, balance, money.ErrCurrencyMismatch)
+		}
+		r.accounts[i].Opening = balance
+		return nil
+	}
+	return fmt.Errorf("unknown account %q", name)
+}
+
+// Lookup ...
+func (r *Registry) Lookup(name Account) (AccountInfo, bool) {
+	for _, account := range r.accounts {
+		if account.Name == name {
+			return account, true
+		}
+	}
+	return AccountInfo{}, false
+}
+
+// Accounts ...
+func (r *Registry) Accounts() []AccountInfo {
+	return append([]AccountInfo{}, r.accounts...)
+}
+
+// First returns the first account of a kind, which is where a plan puts money
+// when nothing says otherwise.
+func (r *Registry) First(kind AccountKind) (Account, bool) {
+	for _, account := range r.accounts {
+		if account.Kind == kind {
+			return account.Name, true
+		}
+	}
+	return "", false
+}
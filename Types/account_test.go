@@ -0,0 +1,75 @@
+package Types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/n8downs/even_challenge/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r, err := NewRegistry(
+		AccountInfo{Name: "Bills", Kind: CheckingAccount},
+		AccountInfo{Name: "Everyday", Kind: CheckingAccount, Opening: money.New(120.)},
+		AccountInfo{Name: "Rainy Day", Kind: SavingsAccount},
+	)
+	assert.Nil(t, err)
+
+	first, ok := r.First(CheckingAccount)
+	assert.True(t, ok)
+	assert.Equal(t, Account("Bills"), first)
+	_, ok = r.First(CreditAccount)
+	assert.False(t, ok)
+
+	everyday, ok := r.Lookup("Everyday")
+	assert.True(t, ok)
+	assert.Equal(t, money.New(120.), everyday.Opening)
+	_, ok = r.Lookup(External)
+	assert.False(t, ok)
+
+	assert.Equal(t, 3, len(r.Accounts()))
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	r := DefaultRegistry()
+	checking, _ := r.First(CheckingAccount)
+	savings, _ := r.First(SavingsAccount)
+	assert.Equal(t, Checking, checking)
+	assert.Equal(t, Savings, savings)
+}
+
+func TestRegisterErrors(t *testing.T) {
+	r := DefaultRegistry()
+	assert.Error(t, r.Register(AccountInfo{Name: Checking}))
+	assert.Error(t, r.Register(AccountInfo{Name: External}))
+	assert.Error(t, r.Register(AccountInfo{}))
+
+	err := r.Register(AccountInfo{Name: "Euro", Currency: money.EUR, Opening: money.NewIn(10., money.USD)})
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+	assert.Nil(t, r.Register(AccountInfo{Name: "Euro", Currency: money.EUR, Opening: money.NewIn(10., money.EUR)}))
+}
+
+func TestSetOpening(t *testing.T) {
+	r := DefaultRegistry()
+	assert.Nil(t, r.SetOpening(Savings, money.New(250.)))
+	savings, _ := r.Lookup(Savings)
+	assert.Equal(t, money.New(250.), savings.Opening)
+
+	assert.Error(t, r.SetOpening("Brokerage", money.New(1.)))
+
+	assert.Nil(t, r.Register(AccountInfo{Name: "Euro", Kind: CashAccount, Currency: money.EUR}))
+	assert.ErrorIs(t, r.SetOpening("Euro", money.NewIn(5., money.USD)), money.ErrCurrencyMismatch)
+}
+
+func TestCreditTerms(t *testing.T) {
+	terms := CreditTerms{Statement: Schedule{Period: Monthly, Date: 10}, DueDays: 21}
+	closing := time.Date(2015, time.August, 10, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2015, time.August, 31, 0, 0, 0, 0, time.UTC), terms.Due(closing))
+
+	r := DefaultRegistry()
+	assert.Nil(t, r.Register(AccountInfo{Name: "Visa", Kind: CreditAccount, Credit: &terms}))
+	assert.Error(t, r.Register(AccountInfo{Name: "Debit", Kind: CheckingAccount, Credit: &terms}))
+	assert.Error(t, r.Register(AccountInfo{Name: "Amex", Kind: CreditAccount, Credit: &CreditTerms{Statement: terms.Statement, DueDays: -1}}))
+	assert.Error(t, r.Register(AccountInfo{Name: "Amex", Kind: CreditAccount, Credit: &CreditTerms{Statement: Schedule{Period: EveryNDays}}}))
+}
@@ -0,0 +1,58 @@
+package Types
+
+import (
+	"time"
+
+	"github.com/n8downs/even_challenge/money"
+)
+
+// Variation changes an amount from one occurrance to the next. The zero value
+// leaves it fixed.
+type Variation struct {
+	// Steps replace the amount from their date on, such as a raise or a new
+	// lease.
+	Steps []Step
+	// Inflation grows the amount by this rate on each anniversary of
+	// InflationSince.
+	Inflation      money.Rate
+	InflationSince time.Time
+	// Seasonal scales the amount in the given months, so 120% makes winter
+	// utilities a fifth higher. Months not listed are unchanged.
+	Seasonal map[time.Month]money.Rate
+	// Table gives the exact amount for particular dates and wins over
+	// everything else.
+	Table map[time.Time]money.Money
+}
+
+// Step ...
+type Step struct {
+	From   time.Time
+	Amount money.Money
+}
+
+// AmountOn applies the variation to amount for an occurrance on date.
+func (v Variation) AmountOn(date time.Time, amount money.Money) money.Money {
+	for d, exact := range v.Table {
+		if sameDay(d, date) {
+			return exact
+		}
+	}
+
+	latest := time.Time{}
+	for _, step := range v.Steps {
+		if DaysBetween(step.From, date) >= 0 && (latest.IsZero() || DaysBetween(latest, step.From) >= 0) {
+			amount, latest = step.Amount, step.From
+		}
+	}
+
+	if !v.Inflation.IsZero() && !v.InflationSince.IsZero() {
+		for year := 1; DaysBetween(v.InflationSince.AddDate(year, 0, 0), date) >= 0; year++ {
+			amount = amount.Add(amount.ApplyRate(v.Inflation, money.HalfEven))
+		}
+	}
+
+	if factor, ok := v.Seasonal[date.Month()]; ok {
+		amount = amount.ApplyRate(factor, money.HalfEven)
+	}
+	return amount
+}
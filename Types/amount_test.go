@@ -0,0 +1,60 @@
+package Types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/n8downs/even_challenge/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedAmount(t *testing.T) {
+	assert.Equal(t, money.New(40.), Variation{}.AmountOn(date("2015.08.04"), money.New(40.)))
+}
+
+func TestSteppedAmount(t *testing.T) {
+	v := Variation{Steps: []Step{
+		{From: date("2016.01.01"), Amount: money.New(1650.)},
+		{From: date("2015.09.01"), Amount: money.New(1600.)},
+	}}
+	assert.Equal(t, money.New(1500.), v.AmountOn(date("2015.08.31"), money.New(1500.)))
+	assert.Equal(t, money.New(1600.), v.AmountOn(date("2015.09.01"), money.New(1500.)))
+	assert.Equal(t, money.New(1650.), v.AmountOn(date("2016.03.01"), money.New(1500.)))
+}
+
+func TestInflatedAmount(t *testing.T) {
+	v := Variation{Inflation: money.Percent(3), InflationSince: date("2015.01.01")}
+	assert.Equal(t, money.New(100.), v.AmountOn(date("2015.12.31"), money.New(100.)))
+	assert.Equal(t, money.New(103.), v.AmountOn(date("2016.01.01"), money.New(100.)))
+	assert.Equal(t, money.New(106.09), v.AmountOn(date("2017.06.01"), money.New(100.)))
+}
+
+func TestSeasonalAmount(t *testing.T) {
+	winter, _ := money.ParseRate("150%")
+	v := Variation{Seasonal: map[time.Month]money.Rate{time.January: winter, time.December: winter}}
+	assert.Equal(t, money.New(63.51), v.AmountOn(date("2015.12.25"), money.New(42.34)))
+	assert.Equal(t, money.New(42.34), v.AmountOn(date("2015.08.25"), money.New(42.34)))
+}
+
+func TestTableWins(t *testing.T) {
+	v := Variation{
+		Steps: []Step{{From: date("2015.01.01"), Amount: money.New(50.)}},
+		Table: map[time.Time]money.Money{date("2015.08.25"): money.New(38.12)},
+	}
+	assert.Equal(t, money.New(38.12), v.AmountOn(date("2015.08.25"), money.New(42.34)))
+	assert.Equal(t, money.New(50.), v.AmountOn(date("2015.09.25"), money.New(42.34)))
+}
+
+func TestOverrideWinsOverVariation(t *testing.T) {
+	expense := Expense{
+		Amount:    money.New(40.),
+		Variation: Variation{Inflation: money.Percent(10), InflationSince: date("2014.01.01")},
+		Schedule: Schedule{
+			Period:    Monthly,
+			Date:      4,
+			Overrides: []Override{{Date: date("2015.09.04"), Amount: money.New(10.)}},
+		},
+	}
+	assert.Equal(t, money.New(44.), expense.AmountOn(date("2015.08.04")))
+	assert.Equal(t, money.New(10.), expense.AmountOn(date("2015.09.04")))
+}
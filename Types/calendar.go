@@ -0,0 +1,209 @@
+package Types
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Holiday ...
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// Calendar decides which days are business days. Generate, when set, adds
+// holidays that follow a rule for each year, such as USFederalHolidays.
+type Calendar struct {
+	Weekend  []time.Weekday
+	Holidays []Holiday
+	Generate func(year int) []Holiday
+}
+
+// Weekends is a calendar with no holidays. Schedules without a Calendar use
+// it.
+var Weekends = Calendar{Weekend: []time.Weekday{time.Saturday, time.Sunday}}
+
+// USFederal ...
+func USFederal() *Calendar {
+	return &Calendar{Weekend: Weekends.Weekend, Generate: USFederalHolidays}
+}
+
+// IsHoliday ...
+func (c Calendar) IsHoliday(date time.Time) bool {
+	for _, holiday := range c.Holidays {
+		if sameDay(holiday.Date, date) {
+			return true
+		}
+	}
+	if c.Generate != nil {
+		// A holiday observed on Dec 31 belongs to the following year.
+		for year := date.Year() - 1; year <= date.Year()+1; year++ {
+			for _, holiday := range c.Generate(year) {
+				if sameDay(holiday.Date, date) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// IsBusinessDay ...
+func (c Calendar) IsBusinessDay(date time.Time) bool {
+	for _, weekday := range c.Weekend {
+		if date.Weekday() == weekday {
+			return false
+		}
+	}
+	return !c.IsHoliday(date)
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// USFederalHolidays lists the federal holidays for a year on the days they
+// are observed: a Saturday holiday moves to Friday and a Sunday holiday to
+// Monday.
+func USFederalHolidays(year int) []Holiday {
+	fixed := func(month time.Month, day int) time.Time {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		switch date.Weekday() {
+		case time.Saturday:
+			return date.AddDate(0, 0, -1)
+		case time.Sunday:
+			return date.AddDate(0, 0, 1)
+		}
+		return date
+	}
+	nth := func(month time.Month, n int, weekday time.Weekday) time.Time {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, offset+7*(n-1))
+	}
+	last := func(month time.Month, weekday time.Weekday) time.Time {
+		end := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		offset := (int(end.Weekday()) - int(weekday) + 7) % 7
+		return end.AddDate(0, 0, -offset)
+	}
+
+	holidays := []Holiday{
+		{fixed(time.January, 1), "New Year's Day"},
+		{nth(time.January, 3, time.Monday), "Martin Luther King Jr. Day"},
+		{nth(time.February, 3, time.Monday), "Washington's Birthday"},
+		{last(time.May, time.Monday), "Memorial Day"},
+	}
+	if year >= 2021 {
+		holidays = append(holidays, Holiday{fixed(time.June, 19), "Juneteenth"})
+	}
+	return append(holidays,
+		Holiday{fixed(time.July, 4), "Independence Day"},
+		Holiday{nth(time.September, 1, time.Monday), "Labor Day"},
+		Holiday{nth(time.October, 2, time.Monday), "Columbus Day"},
+		Holiday{fixed(time.November, 11), "Veterans Day"},
+		Holiday{nth(time.November, 4, time.Thursday), "Thanksgiving Day"},
+		Holiday{fixed(time.December, 25), "Christmas Day"},
+	)
+}
+
+// LoadHolidays reads "date,name" rows, with dates in DateFormat or
+// YYYY-MM-DD. The name is optional.
+func LoadHolidays(r io.Reader) ([]Holiday, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := []Holiday{}
+	for _, row := range rows {
+		if len(row) == 0 || len(row) > 2 {
+			return nil, fmt.Errorf("bad holiday row %q", strings.Join(row, ","))
+		}
+		date, err := parseDate(row[0])
+		if err != nil {
+			return nil, err
+		}
+		holiday := Holiday{Date: date}
+		if len(row) == 2 {
+			holiday.Name = row[1]
+		}
+		holidays = append(holidays, holiday)
+	}
+	return holidays, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range []string{DateFormat, "2006-01-02"} {
+		if date, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("bad date %q", s)
+}
+
+// maxAdjustmentDays bounds how far an occurrance can move, so a calendar
+// without business days can't hang Adjust.
+const maxAdjustmentDays = 14
+
+// Adjustment is the business-day convention for moving an occurrance that
+// lands on a weekend or holiday.
+type Adjustment int
+
+// Adjustments ...
+const (
+	NoAdjustment Adjustment = iota
+	Preceding
+	Following
+	ModifiedFollowing
+)
+
+func (a Adjustment) String() string {
+	switch a {
+	case NoAdjustment:
+		return "NoAdjustment"
+	case Preceding:
+		return "Preceding"
+	case Following:
+		return "Following"
+	case ModifiedFollowing:
+		return "ModifiedFollowing"
+	default:
+		return "???"
+	}
+}
+
+// Adjust moves date to a business day. ModifiedFollowing moves forward
+// unless that would cross into the next month, in which case it moves back.
+func (a Adjustment) Adjust(date time.Time, c Calendar) time.Time {
+	step := func(date time.Time, days int) time.Time {
+		for i := 0; i < maxAdjustmentDays; i++ {
+			if c.IsBusinessDay(date.AddDate(0, 0, i*days)) {
+				return date.AddDate(0, 0, i*days)
+			}
+		}
+		return date
+	}
+
+	switch a {
+	case Preceding:
+		return step(date, -1)
+	case Following:
+		return step(date, 1)
+	case ModifiedFollowing:
+		if following := step(date, 1); following.Month() == date.Month() {
+			return following
+		}
+		return step(date, -1)
+	default:
+		return date
+	}
+}
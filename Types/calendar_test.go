@@ -0,0 +1,128 @@
+package Types
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/n8downs/even_challenge/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUSFederalHolidays(t *testing.T) {
+	holidays := map[string]string{}
+	for _, holiday := range USFederalHolidays(2015) {
+		holidays[holiday.Date.Format(DateFormat)] = holiday.Name
+	}
+	assert.Equal(t, "New Year's Day", holidays["2015.01.01"])
+	assert.Equal(t, "Martin Luther King Jr. Day", holidays["2015.01.19"])
+	assert.Equal(t, "Memorial Day", holidays["2015.05.25"])
+	assert.Equal(t, "Independence Day", holidays["2015.07.03"])
+	assert.Equal(t, "Labor Day", holidays["2015.09.07"])
+	assert.Equal(t, "Thanksgiving Day", holidays["2015.11.26"])
+	assert.Equal(t, "Christmas Day", holidays["2015.12.25"])
+	assert.Equal(t, 10, len(holidays))
+
+	assert.Equal(t, 11, len(USFederalHolidays(2021)))
+}
+
+func TestObservedNewYearsCrossesYears(t *testing.T) {
+	assert.True(t, USFederal().IsHoliday(date("2021.12.31")))
+	assert.False(t, USFederal().IsBusinessDay(date("2021.12.31")))
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	assert.True(t, Weekends.IsBusinessDay(date("2015.08.03")))
+	assert.False(t, Weekends.IsBusinessDay(date("2015.08.01")))
+	assert.False(t, Weekends.IsBusinessDay(date("2015.08.02")))
+	assert.True(t, Weekends.IsBusinessDay(date("2015.09.07")))
+	assert.False(t, USFederal().IsBusinessDay(date("2015.09.07")))
+}
+
+func TestLoadHolidays(t *testing.T) {
+	holidays, err := LoadHolidays(strings.NewReader("# company holidays\n2015.11.27,Day after Thanksgiving\n2015-12-24\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, []Holiday{
+		{date("2015.11.27"), "Day after Thanksgiving"},
+		{date("2015.12.24"), ""},
+	}, holidays)
+
+	_, err = LoadHolidays(strings.NewReader("12/24/2015\n"))
+	assert.Error(t, err)
+}
+
+func TestAdjust(t *testing.T) {
+	saturday := date("2015.08.01")
+	assert.Equal(t, date("2015.07.31"), Preceding.Adjust(saturday, Weekends))
+	assert.Equal(t, date("2015.08.03"), Following.Adjust(saturday, Weekends))
+	assert.Equal(t, date("2015.08.03"), ModifiedFollowing.Adjust(saturday, Weekends))
+	assert.Equal(t, saturday, NoAdjustment.Adjust(saturday, Weekends))
+
+	lastSaturday := date("2015.10.31")
+	assert.Equal(t, date("2015.11.02"), Following.Adjust(lastSaturday, Weekends))
+	assert.Equal(t, date("2015.10.30"), ModifiedFollowing.Adjust(lastSaturday, Weekends))
+
+	laborDaySunday := date("2015.09.06")
+	assert.Equal(t, date("2015.09.08"), Following.Adjust(laborDaySunday, *USFederal()))
+}
+
+func TestAdjustWithoutBusinessDays(t *testing.T) {
+	never := Calendar{Weekend: []time.Weekday{0, 1, 2, 3, 4, 5, 6}}
+	assert.Equal(t, date("2015.08.01"), Following.Adjust(date("2015.08.01"), never))
+}
+
+func TestAdjustedBiMonthlyPaychecks(t *testing.T) {
+	s := Schedule{Period: BiMonthly, Adjustment: Preceding, Calendar: USFederal()}
+	assert.Equal(t,
+		dates("2015.08.14", "2015.09.01", "2015.09.15", "2015.10.01", "2015.10.15", "2015.10.30"),
+		s.FindRealOccurrances(date("2015.08.03"), date("2015.10.31")),
+	)
+}
+
+func TestAdjustedOccurrancesStayInWindow(t *testing.T) {
+	s := Schedule{Period: Monthly, Date: 1, Adjustment: Following}
+	assert.Equal(t,
+		dates("2015.08.03", "2015.09.01"),
+		s.FindRealOccurrances(date("2015.08.01"), date("2015.09.30")),
+	)
+	assert.Equal(t,
+		dates("2015.09.01"),
+		s.FindRealOccurrances(date("2015.08.04"), date("2015.09.30")),
+	)
+
+	s = Schedule{Period: Monthly, Date: 1, Adjustment: Preceding}
+	assert.Equal(t,
+		dates("2015.07.31", "2015.09.01"),
+		s.FindRealOccurrances(date("2015.07.30"), date("2015.09.30")),
+	)
+}
+
+func TestAdjustmentKeepsBiWeeklyPhase(t *testing.T) {
+	plain := Schedule{Period: BiWeekly, Weekday: time.Saturday}
+	adjusted := Schedule{Period: BiWeekly, Weekday: time.Saturday, Adjustment: Preceding}
+	from, to := date("2015.08.01"), date("2015.08.31")
+
+	var expected []time.Time
+	for _, d := range plain.FindRealOccurrances(from, to) {
+		if d.After(from) {
+			expected = append(expected, d.AddDate(0, 0, -1))
+		}
+	}
+	assert.Equal(t, expected, adjusted.FindRealOccurrances(from, to))
+}
+
+func TestAdjustedOccurrancesOnTheSameDayAreKept(t *testing.T) {
+	s := Schedule{Period: Recurring, RRule: "FREQ=DAILY", Adjustment: Preceding}
+	assert.Equal(t,
+		dates(
+			"2015.08.03", "2015.08.04", "2015.08.05", "2015.08.06", "2015.08.07", "2015.08.07", "2015.08.07",
+			"2015.08.10", "2015.08.11", "2015.08.12", "2015.08.13", "2015.08.14", "2015.08.14", "2015.08.14",
+		),
+		s.FindRealOccurrances(date("2015.08.03"), date("2015.08.16")),
+	)
+
+	expense := Expense{Name: "Parking", Amount: money.New(10.), Schedule: s}
+	occurrances := expense.FindVirtualOccurrances(date("2015.08.03"), date("2015.08.09"))
+	assert.Equal(t, money.New(30.), occurrances[date("2015.08.07")])
+	assert.Equal(t, money.New(10.), occurrances[date("2015.08.06")])
+}
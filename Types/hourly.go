@@ -0,0 +1,97 @@
+package Types
+
+import (
+	"errors"
+	"time"
+
+	"github.com/n8downs/even_challenge/money"
+)
+
+// Hourly describes pay earned by the hour. Shifts are worked on the Shifts
+// schedule and paid by pay period: the period starting on PayPeriodStart
+// runs PayPeriodDays (7 when zero) and is paid PaydayOffset days after its
+// last day, so a Monday-Sunday week paid the following Friday has an offset
+// of 5.
+type Hourly struct {
+	Rate           money.Money
+	ShiftLength    time.Duration
+	Shifts         Schedule
+	PayPeriodStart time.Time
+	PayPeriodDays  int
+	PaydayOffset   int
+}
+
+// IsZero ...
+func (h Hourly) IsZero() bool {
+	return h.Rate.EqualTo(money.Money{}) && h.ShiftLength == 0 && h.PayPeriodStart.IsZero()
+}
+
+// Validate ...
+func (h Hourly) Validate() error {
+	switch {
+	case !h.Rate.GreaterThan(money.NewIn(0., h.Rate.Currency())):
+		return errors.New("hourly rate must be positive")
+	case h.ShiftLength <= 0:
+		return errors.New("hourly shifts need a positive ShiftLength")
+	case h.PayPeriodStart.IsZero():
+		return errors.New("hourly pay needs a PayPeriodStart")
+	case h.PayPeriodDays < 0 || h.PaydayOffset < 0:
+		return errors.New("hourly pay periods can't be negative")
+	}
+	return h.Shifts.Validate()
+}
+
+func (h Hourly) periodDays() int {
+	if h.PayPeriodDays == 0 {
+		return 7
+	}
+	return h.PayPeriodDays
+}
+
+// payday returns the nth pay period's start and payday as UTC civil dates.
+// Period 0 starts on PayPeriodStart.
+func (h Hourly) payday(n int) (start, payday time.Time) {
+	days := h.periodDays()
+	start = CivilDate(h.PayPeriodStart, time.UTC).AddDate(0, 0, n*days)
+	return start, start.AddDate(0, 0, days-1+h.PaydayOffset)
+}
+
+// period finds the pay period paid on date.
+func (h Hourly) period(date time.Time) (n int, ok bool) {
+	days := h.periodDays()
+	offset := DaysBetween(h.PayPeriodStart, date) - (days - 1 + h.PaydayOffset)
+	if offset%days != 0 {
+		return 0, false
+	}
+	return offset / days, true
+}
+
+// Paydays returns civil dates at midnight in from's zone.
+func (h Hourly) Paydays(from, to time.Time) (paydays []time.Time) {
+	days := h.periodDays()
+	n := (DaysBetween(h.PayPeriodStart, from) - (days - 1 + h.PaydayOffset)) / days
+	first, last := CivilDate(from, time.UTC), CivilDate(to, time.UTC)
+	for n--; ; n++ {
+		_, payday := h.payday(n)
+		if payday.After(last) {
+			break
+		}
+		if !payday.Before(first) {
+			paydays = append(paydays, CivilDate(payday, from.Location()))
+		}
+	}
+	return
+}
+
+// PayOn returns the pay for the shifts in the period paid on date, at rate.
+func (h Hourly) PayOn(date time.Time, rate money.Money) money.Money {
+	n, ok := h.period(date)
+	if !ok {
+		return money.NewIn(0., rate.Currency())
+	}
+	start, _ := h.payday(n)
+	end := start.AddDate(0, 0, h.periodDays()-1)
+	shifts := len(h.Shifts.FindRealOccurrances(start, end))
+	hours := (time.Duration(shifts) * h.ShiftLength).Hours()
+	return rate.MultiplyRounded(hours, money.HalfEven)
+}
@@ -0,0 +1,71 @@
+package Types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/n8downs/even_challenge/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func barista() Hourly {
+	return Hourly{
+		Rate:           money.New(15.50),
+		ShiftLength:    6 * time.Hour,
+		Shifts:         Schedule{Period: Recurring, RRule: "FREQ=WEEKLY;BYDAY=MO,WE,FR"},
+		PayPeriodStart: date("2015.08.03"),
+		PaydayOffset:   5,
+	}
+}
+
+func TestHourlyPaydays(t *testing.T) {
+	assert.Equal(t,
+		[]time.Time{date("2015.08.07"), date("2015.08.14"), date("2015.08.21"), date("2015.08.28")},
+		barista().Paydays(date("2015.08.01"), date("2015.08.31")),
+	)
+
+	h := barista()
+	h.PayPeriodDays = 14
+	assert.Equal(t,
+		[]time.Time{date("2015.08.07"), date("2015.08.21")},
+		h.Paydays(date("2015.08.01"), date("2015.08.31")),
+	)
+}
+
+func TestHourlyPay(t *testing.T) {
+	h := barista()
+	assert.Equal(t, money.New(279.), h.PayOn(date("2015.08.14"), h.Rate))
+	assert.Equal(t, money.New(0.), h.PayOn(date("2015.08.13"), h.Rate))
+
+	h.ShiftLength = 7*time.Hour + 30*time.Minute
+	h.Shifts.Except = []time.Time{date("2015.08.05")}
+	assert.Equal(t, money.New(232.50), h.PayOn(date("2015.08.14"), h.Rate))
+}
+
+func TestHourlyIncome(t *testing.T) {
+	income := Income{
+		Name:   "Philz",
+		Hourly: barista(),
+		Variation: Variation{Steps: []Step{
+			{From: date("2015.08.20"), Amount: money.New(16.)},
+		}},
+	}
+	assert.Nil(t, income.Validate())
+	assert.Equal(t, 4, len(income.FindRealOccurrances(date("2015.08.01"), date("2015.08.31"))))
+	assert.Equal(t, money.New(279.), income.AmountOn(date("2015.08.14")))
+	assert.Equal(t, money.New(288.), income.AmountOn(date("2015.08.21")))
+}
+
+func TestInvalidHourly(t *testing.T) {
+	h := barista()
+	h.ShiftLength = 0
+	assert.Error(t, Income{Hourly: h}.Validate())
+
+	h = barista()
+	h.PayPeriodStart = time.Time{}
+	assert.Error(t, Income{Hourly: h}.Validate())
+
+	h = barista()
+	h.Shifts.RRule = "FREQ=FORTNIGHTLY"
+	assert.Error(t, Income{Hourly: h}.Validate())
+}
@@ -0,0 +1,569 @@
+package Types
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/n8downs/even_challenge/money"
+)
+
+// textDateFormat is how dates are written in schedule text.
+const textDateFormat = "2006-01-02"
+
+// ParseSchedule reads a schedule written as a phrase, such as
+//
+//	every other Thursday starting 2015-08-06
+//	the 1st and 15th
+//	the 15th and last day
+//	monthly on the 28th
+//	last business day of each month
+//	once on 2015-12-25
+//	RRULE:FREQ=MONTHLY;BYDAY=-1FR
+//
+// Periodic phrases may be followed by "starting <date>", "anchored <date>",
+// "until <date>", "<n> times", "or the business day before" (or "after", or
+// "after within the month"), "except <date> [and <date> ...]" and overrides
+// such as "with <date> moved to <date>" or "with <date> paying <amount>". An
+// RRULE may also be followed by "from <date>", its DTSTART. Schedule.String
+// writes the canonical form back out.
+func ParseSchedule(text string) (Schedule, error) {
+	text = strings.TrimSpace(text)
+	p := &scheduleParser{text: text, words: strings.Fields(strings.ToLower(text))}
+	var s Schedule
+	if fields := strings.Fields(text); len(fields) > 0 && len(fields[0]) >= 6 && strings.EqualFold(fields[0][:6], "RRULE:") {
+		s, p.pos = Schedule{Period: Recurring, RRule: fields[0][6:]}, 1
+		if s.RRule == "" && len(fields) > 1 {
+			s.RRule, p.pos = fields[1], 2
+		}
+	} else {
+		var err error
+		if s, err = p.schedule(); err != nil {
+			return Schedule{}, err
+		}
+	}
+	if err := p.suffixes(&s); err != nil {
+		return Schedule{}, err
+	}
+	if word, ok := p.peek(); ok {
+		return Schedule{}, p.errorf("unexpected %q", word)
+	}
+
+	// Phased periods take their phase from the start unless anchored. A
+	// weekly one only takes its weekday, so it can start on any day.
+	if phased(s.Period) && s.Anchor.IsZero() && !(s.Period == Weekly && s.Start.Weekday() != s.Weekday) {
+		s.Anchor = s.Start
+	}
+	if (s.Period == Weekly || s.Period == BiWeekly) && !s.Anchor.IsZero() && s.Anchor.Weekday() != s.Weekday {
+		return Schedule{}, p.errorf("starts on a %s, not a %s", s.Anchor.Weekday(), s.Weekday)
+	}
+	return s, s.Validate()
+}
+
+type scheduleParser struct {
+	text  string
+	words []string
+	pos   int
+}
+
+func (p *scheduleParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid schedule %q: %s", p.text, fmt.Sprintf(format, args...))
+}
+
+func (p *scheduleParser) peek() (string, bool) {
+	if p.pos >= len(p.words) {
+		return "", false
+	}
+	return p.words[p.pos], true
+}
+
+func (p *scheduleParser) next() (string, error) {
+	word, ok := p.peek()
+	if !ok {
+		return "", p.errorf("unexpected end")
+	}
+	p.pos++
+	return word, nil
+}
+
+// accept consumes words if they come next.
+func (p *scheduleParser) accept(words ...string) bool {
+	if p.pos+len(words) > len(p.words) {
+		return false
+	}
+	for i, word := range words {
+		if p.words[p.pos+i] != word {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *scheduleParser) expect(words ...string) error {
+	if !p.accept(words...) {
+		return p.errorf("expected %q", strings.Join(words, " "))
+	}
+	return nil
+}
+
+func (p *scheduleParser) schedule() (Schedule, error) {
+	switch {
+	case p.accept("once", "on"), p.accept("on"):
+		date, err := p.date()
+		return Schedule{Period: OneTime, Time: date}, err
+	case p.accept("twice", "a", "month"):
+		return Schedule{Period: BiMonthly}, nil
+	case p.accept("last", "business", "day", "of", "each", "month"):
+		return Schedule{Period: Monthly, Date: LastDayOfMonth, Adjustment: Preceding}, nil
+	case p.accept("first", "business", "day", "of", "each", "month"):
+		return Schedule{Period: Monthly, Date: 1, Adjustment: Following}, nil
+	case p.accept("the"):
+		var days []int
+		for {
+			day, err := p.dayOfMonth()
+			if err != nil {
+				return Schedule{}, err
+			}
+			days = append(days, day)
+			if !p.accept("and") {
+				break
+			}
+		}
+		if len(days) == 1 {
+			return Schedule{Period: Monthly, Date: days[0]}, p.expect("of", "each", "month")
+		}
+		p.accept("of", "each", "month")
+		if len(days) == 2 && days[0] == 1 && days[1] == 15 {
+			days = nil
+		}
+		return Schedule{Period: BiMonthly, Days: days}, nil
+	case p.accept("daily"), p.accept("every", "day"):
+		return Schedule{Period: EveryNDays, Interval: 1}, nil
+	case p.accept("weekly", "on"):
+		weekday, err := p.weekday()
+		return Schedule{Period: Weekly, Weekday: weekday}, err
+	case p.accept("biweekly", "on"), p.accept("every", "other"):
+		weekday, err := p.weekday()
+		return Schedule{Period: BiWeekly, Weekday: weekday}, err
+	case p.accept("monthly"):
+		return p.onDay(Schedule{Period: Monthly})
+	case p.accept("quarterly"):
+		return p.onDay(Schedule{Period: Quarterly})
+	case p.accept("semiannually"):
+		return p.onDay(Schedule{Period: SemiAnnually})
+	case p.accept("annually"), p.accept("yearly"):
+		return p.onDay(Schedule{Period: Annually})
+	case p.accept("every"):
+		word, err := p.next()
+		if err != nil {
+			return Schedule{}, err
+		}
+		n, err := strconv.Atoi(word)
+		if err != nil {
+			p.pos--
+			weekday, err := p.weekday()
+			return Schedule{Period: Weekly, Weekday: weekday}, err
+		}
+		switch {
+		case p.accept("days"):
+			return Schedule{Period: EveryNDays, Interval: n}, nil
+		case p.accept("months"):
+			return p.onDay(Schedule{Period: EveryNMonths, Interval: n})
+		}
+		return Schedule{}, p.errorf("expected \"days\" or \"months\" after %d", n)
+	}
+	if word, ok := p.peek(); ok {
+		return Schedule{}, p.errorf("unexpected %q", word)
+	}
+	return Schedule{}, p.errorf("empty schedule")
+}
+
+// onDay reads an optional "on the <day>" for month-based periods.
+func (p *scheduleParser) onDay(s Schedule) (Schedule, error) {
+	if !p.accept("on", "the") {
+		return s, nil
+	}
+	day, err := p.dayOfMonth()
+	s.Date = day
+	return s, err
+}
+
+// dayOfMonth reads "28th", "last day" or "3rd to last day".
+func (p *scheduleParser) dayOfMonth() (int, error) {
+	if p.accept("last", "day") {
+		return LastDayOfMonth, nil
+	}
+	word, err := p.next()
+	if err != nil {
+		return 0, err
+	}
+	day, ok := parseOrdinal(word)
+	if !ok || day > 31 {
+		return 0, p.errorf("bad day of month %q", word)
+	}
+	if p.accept("to", "last", "day") {
+		return -day, nil
+	}
+	return day, nil
+}
+
+func (p *scheduleParser) weekday() (time.Weekday, error) {
+	word, err := p.next()
+	if err != nil {
+		return 0, err
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if word == name || word == name+"s" {
+			return weekday, nil
+		}
+	}
+	return 0, p.errorf("bad weekday %q", word)
+}
+
+func (p *scheduleParser) date() (time.Time, error) {
+	word, err := p.next()
+	if err != nil {
+		return time.Time{}, err
+	}
+	date, err := parseDate(word)
+	if err != nil {
+		return time.Time{}, p.errorf("bad date %q", word)
+	}
+	return date, nil
+}
+
+func (p *scheduleParser) amount() (money.Money, error) {
+	word, err := p.next()
+	if err != nil {
+		return money.Money{}, err
+	}
+	if code, ok := p.peek(); ok && len(code) == 3 && code != "and" && strings.Trim(code, "abcdefghijklmnopqrstuvwxyz") == "" {
+		word += " " + code
+		p.pos++
+	}
+	m, err := money.Parse(strings.ToUpper(word))
+	if err != nil {
+		return money.Money{}, p.errorf("bad amount %q", word)
+	}
+	return m, nil
+}
+
+func (p *scheduleParser) suffixes(s *Schedule) error {
+	for {
+		switch {
+		case p.accept("starting"):
+			date, err := p.date()
+			if err != nil {
+				return err
+			}
+			if s.Period == OneTime {
+				return p.errorf("a one time schedule can't have a start")
+			}
+			s.Start = date
+		case p.accept("anchored"):
+			date, err := p.date()
+			if err != nil {
+				return err
+			}
+			if s.Period == OneTime {
+				return p.errorf("a one time schedule can't have an anchor")
+			}
+			s.Anchor = date
+		case p.accept("from"):
+			date, err := p.date()
+			if err != nil {
+				return err
+			}
+			if s.Period != Recurring {
+				return p.errorf("only an RRULE counts from a date")
+			}
+			s.Time = date
+		case p.accept("except"):
+			for {
+				date, err := p.date()
+				if err != nil {
+					return err
+				}
+				s.Except = append(s.Except, date)
+				if !p.accept("and") {
+					break
+				}
+			}
+		case p.accept("with"):
+			date, err := p.date()
+			if err != nil {
+				return err
+			}
+			o := Override{Date: date}
+			if p.accept("moved", "to") {
+				if o.MoveTo, err = p.date(); err != nil {
+					return err
+				}
+			}
+			if p.accept("paying") {
+				if o.Amount, err = p.amount(); err != nil {
+					return err
+				}
+			}
+			if o.MoveTo.IsZero() && o.Amount.EqualTo(money.Money{}) {
+				return p.errorf("expected \"moved to\" or \"paying\" after %s", date.Format(textDateFormat))
+			}
+			s.Overrides = append(s.Overrides, o)
+		case p.accept("until"):
+			date, err := p.date()
+			if err != nil {
+				return err
+			}
+			s.End = date
+		case p.accept("or", "the", "business", "day", "before"):
+			s.Adjustment = Preceding
+		case p.accept("or", "the", "business", "day", "after", "within", "the", "month"):
+			s.Adjustment = ModifiedFollowing
+		case p.accept("or", "the", "business", "day", "after"):
+			s.Adjustment = Following
+		default:
+			word, ok := p.peek()
+			if !ok {
+				return nil
+			}
+			n, err := strconv.Atoi(word)
+			if err != nil || n <= 0 || p.pos+1 >= len(p.words) || p.words[p.pos+1] != "times" {
+				return nil
+			}
+			p.pos += 2
+			s.Count = n
+		}
+	}
+}
+
+// phased periods take their phase from a start date.
+func phased(period Period) bool {
+	switch period {
+	case Weekly, BiWeekly, Quarterly, SemiAnnually, Annually, EveryNMonths, EveryNDays:
+		return true
+	}
+	return false
+}
+
+func parseOrdinal(word string) (int, bool) {
+	if len(word) < 3 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(word[:len(word)-2])
+	if err != nil || n <= 0 || ordinal(n) != word {
+		return 0, false
+	}
+	return n, true
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+func dayText(day int) string {
+	switch {
+	case day == LastDayOfMonth:
+		return "last day"
+	case day < 0:
+		return ordinal(-day) + " to last day"
+	default:
+		return ordinal(day)
+	}
+}
+
+// String writes the schedule in the form ParseSchedule reads. Fields that
+// have no wording, a Calendar or a Location, are left out; MarshalText
+// refuses those.
+func (s Schedule) String() string {
+	adjustment, onDay := s.Adjustment, true
+	weekday := s.Weekday
+	if !s.Anchor.IsZero() {
+		weekday = s.Anchor.Weekday()
+	}
+	var words []string
+	switch s.Period {
+	case Recurring:
+		words = append(words, "RRULE:"+s.RRule)
+	case OneTime:
+		words = append(words, "once on", s.Time.Format(textDateFormat))
+	case BiMonthly:
+		days := []string{}
+		for _, day := range s.Days {
+			days = append(days, dayText(day))
+		}
+		if len(days) == 0 {
+			days = []string{"1st", "15th"}
+		}
+		words = append(words, "the", strings.Join(days, " and "))
+	case Monthly:
+		switch {
+		case s.Date == LastDayOfMonth && adjustment == Preceding:
+			words, adjustment, onDay = append(words, "last business day of each month"), NoAdjustment, false
+		case s.Date == 1 && adjustment == Following:
+			words, adjustment, onDay = append(words, "first business day of each month"), NoAdjustment, false
+		default:
+			words = append(words, "monthly")
+		}
+	case Weekly:
+		words = append(words, "every", weekday.String())
+	case BiWeekly:
+		words = append(words, "every other", weekday.String())
+	case Quarterly:
+		words = append(words, "quarterly")
+	case SemiAnnually:
+		words = append(words, "semiannually")
+	case Annually:
+		words = append(words, "annually")
+	case EveryNMonths:
+		words = append(words, "every", strconv.Itoa(s.Interval), "months")
+	case EveryNDays:
+		if s.Interval == 1 {
+			words = append(words, "every day")
+		} else {
+			words = append(words, "every", strconv.Itoa(s.Interval), "days")
+		}
+	default:
+		return s.Period.String()
+	}
+	switch s.Period {
+	case Monthly, Quarterly, SemiAnnually, Annually, EveryNMonths:
+		if onDay && s.Date != 0 {
+			words = append(words, "on the", dayText(s.Date))
+		}
+	}
+
+	if !s.Start.IsZero() {
+		words = append(words, "starting", s.Start.Format(textDateFormat))
+	}
+	if !s.Anchor.IsZero() && !(phased(s.Period) && sameDay(s.Anchor, s.Start)) && !(s.Period == Weekly && !s.countsFromAnchor()) {
+		words = append(words, "anchored", s.Anchor.Format(textDateFormat))
+	}
+	if s.Period == Recurring && !s.Time.IsZero() {
+		words = append(words, "from", s.Time.Format(textDateFormat))
+	}
+	if !s.End.IsZero() {
+		words = append(words, "until", s.End.Format(textDateFormat))
+	}
+	if s.Count > 0 {
+		words = append(words, strconv.Itoa(s.Count), "times")
+	}
+	switch adjustment {
+	case Preceding:
+		words = append(words, "or the business day before")
+	case Following:
+		words = append(words, "or the business day after")
+	case ModifiedFollowing:
+		words = append(words, "or the business day after within the month")
+	}
+	for i, date := range s.Except {
+		if i == 0 {
+			words = append(words, "except")
+		} else {
+			words = append(words, "and")
+		}
+		words = append(words, date.Format(textDateFormat))
+	}
+	for _, o := range s.Overrides {
+		words = append(words, "with", o.Date.Format(textDateFormat))
+		if !o.MoveTo.IsZero() {
+			words = append(words, "moved to", o.MoveTo.Format(textDateFormat))
+		}
+		if !o.Amount.EqualTo(money.Money{}) {
+			amount, _ := o.Amount.MarshalText()
+			words = append(words, "paying", string(amount))
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// MarshalText writes String, or fails for a schedule the text would read back
+// differently, such as one with a Calendar or Location.
+func (s Schedule) MarshalText() ([]byte, error) {
+	text := s.String()
+	parsed, err := ParseSchedule(text)
+	if err != nil {
+		return nil, fmt.Errorf("schedule can't be written as text: %w", err)
+	}
+	if !reflect.DeepEqual(parsed.canonical(), s.canonical()) {
+		return nil, fmt.Errorf("schedule can't be written as text: %q would lose some of it", text)
+	}
+	return []byte(text), nil
+}
+
+func (s Schedule) countsFromAnchor() bool {
+	return s.Count > 0 && s.Start.IsZero()
+}
+
+// canonical clears the fields s's Period doesn't read and makes its dates
+// civil, so schedules that give the same dates compare equal.
+func (s Schedule) canonical() Schedule {
+	s = s.inUTC()
+	c := Schedule{
+		Period:     s.Period,
+		Anchor:     s.Anchor,
+		Adjustment: s.Adjustment,
+		Calendar:   s.Calendar,
+		Start:      s.Start,
+		End:        s.End,
+		Count:      s.Count,
+		Except:     s.Except,
+		Overrides:  s.Overrides,
+		Location:   s.Location,
+	}
+	switch s.Period {
+	case Weekly:
+		// Only the Anchor's weekday matters to a weekly schedule, unless
+		// its Count is counted from it.
+		c.Weekday = s.Weekday
+		if !s.Anchor.IsZero() {
+			c.Weekday = s.Anchor.Weekday()
+		}
+		if !s.countsFromAnchor() {
+			c.Anchor = time.Time{}
+		}
+	case BiWeekly:
+		c.Weekday = s.Weekday
+		if !s.Anchor.IsZero() {
+			c.Weekday = s.Anchor.Weekday()
+		}
+	case BiMonthly:
+		if len(s.Days) > 0 && !(len(s.Days) == 2 && s.Days[0] == 1 && s.Days[1] == 15) {
+			c.Days = s.Days
+		}
+	case Monthly, Quarterly, SemiAnnually, Annually:
+		c.Date = s.Date
+	case EveryNMonths:
+		c.Date, c.Interval = s.Date, s.Interval
+	case EveryNDays:
+		c.Interval = s.Interval
+	case OneTime:
+		c.Time = s.Time
+	case Recurring:
+		c.Time, c.RRule = s.Time, s.RRule
+	}
+	return c
+}
+
+// UnmarshalText ...
+func (s *Schedule) UnmarshalText(text []byte) error {
+	parsed, err := ParseSchedule(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
@@ -0,0 +1,194 @@
+package Types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/n8downs/even_challenge/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func parse(t *testing.T, text string) Schedule {
+	s, err := ParseSchedule(text)
+	assert.Nil(t, err, text)
+	return s
+}
+
+func TestParseSchedule(t *testing.T) {
+	assert.Equal(t,
+		Schedule{Period: BiWeekly, Weekday: time.Thursday, Start: date("2015.08.06"), Anchor: date("2015.08.06")},
+		parse(t, "every other Thursday starting 2015-08-06"),
+	)
+	assert.Equal(t, Schedule{Period: BiMonthly}, parse(t, "the 1st and 15th"))
+	assert.Equal(t, Schedule{Period: BiMonthly, Days: []int{7, 22}}, parse(t, "the 7th and 22nd of each month"))
+	assert.Equal(t, Schedule{Period: BiMonthly, Days: []int{15, LastDayOfMonth}}, parse(t, "the 15th and last day"))
+	assert.Equal(t, Schedule{Period: Monthly, Date: 28}, parse(t, "monthly on the 28th"))
+	assert.Equal(t, Schedule{Period: Monthly, Date: 28}, parse(t, "the 28th of each month"))
+	assert.Equal(t,
+		Schedule{Period: Monthly, Date: LastDayOfMonth, Adjustment: Preceding},
+		parse(t, "last business day of each month"),
+	)
+	assert.Equal(t, Schedule{Period: OneTime, Time: date("2015.12.25")}, parse(t, "once on 2015-12-25"))
+	assert.Equal(t, Schedule{Period: Weekly, Weekday: time.Tuesday}, parse(t, "Every  Tuesday"))
+	assert.Equal(t, Schedule{Period: Weekly, Weekday: time.Tuesday}, parse(t, "weekly on tuesdays"))
+	assert.Equal(t, Schedule{Period: Monthly, Date: -3}, parse(t, "monthly on the 3rd to last day"))
+	assert.Equal(t,
+		Schedule{Period: EveryNMonths, Interval: 2, Date: 31, Start: date("2015.08.31"), Anchor: date("2015.08.31")},
+		parse(t, "every 2 months on the 31st starting 2015.08.31"),
+	)
+	assert.Equal(t,
+		Schedule{Period: Weekly, Weekday: time.Tuesday, End: date("2016.03.29")},
+		parse(t, "every Tuesday until 2016-03-29"),
+	)
+	assert.Equal(t,
+		Schedule{Period: Monthly, Date: 15, Start: date("2015.08.15"), Count: 12, Adjustment: Following},
+		parse(t, "monthly on the 15th starting 2015-08-15 12 times or the business day after"),
+	)
+	assert.Equal(t,
+		Schedule{Period: Recurring, RRule: "FREQ=MONTHLY;BYDAY=-1FR"},
+		parse(t, "rrule:FREQ=MONTHLY;BYDAY=-1FR"),
+	)
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"fortnightly",
+		"every other Thursday starting 2015-08-07",
+		"monthly on the 32nd",
+		"monthly on the 2th",
+		"the 28th",
+		"every 3 weeks",
+		"every 0 days",
+		"every 10 days",
+		"monthly on the 15th 12 times",
+		"every 2 months on the 31st",
+		"once on Christmas",
+		"once on 2015-12-25 starting 2015-12-01",
+		"every Tuesday please",
+		"RRULE:FREQ=FORTNIGHTLY",
+		"every Tuesday anchored 2015-08-12",
+		"monthly on the 28th with 2015-08-28",
+		"monthly on the 28th with 2015-08-28 paying lots",
+		"every Tuesday from 2015-08-04",
+	} {
+		_, err := ParseSchedule(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestScheduleStringRoundTrips(t *testing.T) {
+	for _, text := range []string{
+		"every other Thursday starting 2015-08-06",
+		"the 1st and 15th",
+		"the 15th and last day or the business day before",
+		"the 5th and 10th and 20th",
+		"monthly on the 28th",
+		"last business day of each month",
+		"first business day of each month",
+		"once on 2015-12-25",
+		"every Tuesday until 2016-03-29",
+		"monthly on the last day or the business day after",
+		"monthly on the 2nd to last day starting 2015-08-01 6 times",
+		"quarterly on the 15th starting 2015-02-15",
+		"semiannually starting 2015-01-20",
+		"annually on the 1st starting 2014-10-01 or the business day after within the month",
+		"every 2 months on the 31st starting 2015-08-31",
+		"every day",
+		"every 10 days starting 2015-07-28",
+		"RRULE:FREQ=MONTHLY;BYDAY=-1FR",
+		"every other Thursday anchored 2015-08-13",
+		"monthly anchored 2015-02-15",
+		"every other Thursday starting 2015-09-01 anchored 2015-08-13",
+		"every Tuesday starting 2015-08-12",
+		"RRULE:FREQ=WEEKLY;INTERVAL=3 starting 2015-08-20 from 2015-08-06 until 2015-12-31 4 times or the business day after",
+		"every Tuesday except 2015-08-11 and 2015-08-25",
+		"monthly on the 28th with 2015-08-28 moved to 2015-09-28 with 2015-10-28 paying 75.00 EUR",
+	} {
+		s := parse(t, text)
+		assert.Equal(t, text, s.String())
+		assert.Equal(t, s, parse(t, s.String()))
+	}
+}
+
+func TestScheduleStringUsesAnchorWeekday(t *testing.T) {
+	s := Schedule{Period: BiWeekly, Anchor: date("2015.08.13")}
+	assert.Equal(t, "every other Thursday anchored 2015-08-13", s.String())
+	text, err := s.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, s.String(), string(text))
+}
+
+func TestScheduleStringKeepsEverything(t *testing.T) {
+	for _, s := range []Schedule{
+		{Period: Monthly, Anchor: date("2015.02.15")},
+		{Period: BiWeekly, Weekday: time.Thursday, Start: date("2015.09.01"), Anchor: date("2015.08.13")},
+		{Period: Weekly, Weekday: time.Tuesday, Start: date("2015.08.12")},
+		{Period: Weekly, Weekday: time.Tuesday, Anchor: date("2015.08.04"), Count: 3},
+		{
+			Period:     Recurring,
+			RRule:      "FREQ=WEEKLY;INTERVAL=3",
+			Time:       date("2015.08.06"),
+			Start:      date("2015.08.20"),
+			End:        date("2015.12.31"),
+			Count:      4,
+			Adjustment: Following,
+		},
+		{Period: Recurring, RRule: "FREQ=MONTHLY;BYDAY=-1FR", Anchor: date("2015.01.30"), Start: date("2015.03.01")},
+		{Period: Weekly, Weekday: time.Tuesday, Except: []time.Time{date("2015.08.11"), date("2015.08.25")}},
+		{
+			Period: Monthly,
+			Date:   28,
+			Overrides: []Override{
+				{Date: date("2015.08.28"), MoveTo: date("2015.09.28")},
+				{Date: date("2015.10.28"), Amount: money.NewIn(75., money.EUR)},
+				{Date: date("2015.11.28"), MoveTo: date("2015.11.30"), Amount: money.New(380.)},
+			},
+		},
+	} {
+		text, err := s.MarshalText()
+		assert.Nil(t, err, s.String())
+
+		var parsed Schedule
+		assert.Nil(t, parsed.UnmarshalText(text))
+		assert.Equal(t, s.String(), parsed.String())
+		for _, window := range [][2]time.Time{
+			{date("2015.07.01"), date("2015.12.31")},
+			{date("2015.08.15"), date("2016.02.28")},
+		} {
+			assert.Equal(t, s.FindRealOccurrances(window[0], window[1]), parsed.FindRealOccurrances(window[0], window[1]), string(text))
+		}
+	}
+}
+
+func TestScheduleMarshalTextRefusesWhatItWouldLose(t *testing.T) {
+	for _, s := range []Schedule{
+		{Period: BiMonthly, Adjustment: Preceding, Calendar: USFederal()},
+		{Period: Monthly, Date: 28, Location: time.FixedZone("PDT", -7*60*60)},
+		{Period: BiWeekly, Weekday: time.Thursday, Start: date("2015.08.13")},
+		{Period: EveryNDays, Interval: 10},
+	} {
+		_, err := s.MarshalText()
+		assert.Error(t, err, s.String())
+	}
+
+	_, err := json.Marshal(Expense{Name: "Rent", Schedule: Schedule{Period: Monthly, Date: 28, Calendar: USFederal()}})
+	assert.Error(t, err)
+}
+
+func TestScheduleJSON(t *testing.T) {
+	var expense struct {
+		Name     string
+		Schedule Schedule
+	}
+	err := json.Unmarshal([]byte(`{"Name": "Rent", "Schedule": "monthly on the 28th"}`), &expense)
+	assert.Nil(t, err)
+	assert.Equal(t, Schedule{Period: Monthly, Date: 28}, expense.Schedule)
+
+	out, err := json.Marshal(expense)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Name":"Rent","Schedule":"monthly on the 28th"}`, string(out))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"Schedule": "fortnightly"}`), &expense))
+}
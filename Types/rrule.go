@@ -0,0 +1,443 @@
+package Types
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency ...
+type Frequency int
+
+// Frequencies ...
+const (
+	FreqDaily Frequency = iota
+	FreqWeekly
+	FreqMonthly
+	FreqYearly
+)
+
+var frequencyNames = map[Frequency]string{
+	FreqDaily:   "DAILY",
+	FreqWeekly:  "WEEKLY",
+	FreqMonthly: "MONTHLY",
+	FreqYearly:  "YEARLY",
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+// WeekdayNum is an RRULE BYDAY entry: a weekday with an optional ordinal,
+// so {-1, time.Friday} is the last Friday of the month.
+type WeekdayNum struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// RRule is the subset of an RFC 5545 recurrence rule that schedules need.
+type RRule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+}
+
+// ParseRRule parses a rule such as "FREQ=MONTHLY;BYDAY=-1FR". A leading
+// "RRULE:" is allowed.
+func ParseRRule(s string) (RRule, error) {
+	fail := func(format string, args ...interface{}) (RRule, error) {
+		return RRule{}, fmt.Errorf("invalid RRULE %q: %s", s, fmt.Sprintf(format, args...))
+	}
+
+	rule := RRule{Freq: -1, Interval: 1, WeekStart: time.Monday}
+	body := strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	seen := map[string]bool{}
+	for _, part := range strings.Split(body, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return fail("malformed part %q", part)
+		}
+		key, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+		if seen[key] {
+			return fail("%s given twice", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			found := false
+			for freq, name := range frequencyNames {
+				if name == value {
+					rule.Freq, found = freq, true
+				}
+			}
+			if !found {
+				return fail("unsupported FREQ %s", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return fail("bad INTERVAL %s", value)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return fail("bad COUNT %s", value)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseRRuleDate(value)
+			if err != nil {
+				return fail("bad UNTIL %s", value)
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, item := range strings.Split(value, ",") {
+				day, err := parseWeekdayNum(item)
+				if err != nil {
+					return fail("bad BYDAY %s", item)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, item := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(item)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return fail("bad BYMONTHDAY %s", item)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, item := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(item)
+				if err != nil || n < 1 || n > 12 {
+					return fail("bad BYMONTH %s", item)
+				}
+				rule.ByMonth = append(rule.ByMonth, time.Month(n))
+			}
+		case "WKST":
+			day, err := parseWeekdayNum(value)
+			if err != nil || day.Ordinal != 0 {
+				return fail("bad WKST %s", value)
+			}
+			rule.WeekStart = day.Weekday
+		default:
+			return fail("unsupported part %s", key)
+		}
+	}
+
+	if rule.Freq < 0 {
+		return fail("missing FREQ")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return fail("COUNT and UNTIL are mutually exclusive")
+	}
+	for _, day := range rule.ByDay {
+		if day.Ordinal != 0 && rule.Freq != FreqMonthly && rule.Freq != FreqYearly {
+			return fail("BYDAY ordinals need FREQ=MONTHLY or FREQ=YEARLY")
+		}
+	}
+	return rule, nil
+}
+
+func parseRRuleDate(s string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", s); err == nil {
+		return t, nil
+	}
+	return time.Parse("20060102", s)
+}
+
+func parseWeekdayNum(s string) (WeekdayNum, error) {
+	if len(s) < 2 {
+		return WeekdayNum{}, fmt.Errorf("too short")
+	}
+	name, prefix := s[len(s)-2:], s[:len(s)-2]
+	for weekday, n := range weekdayNames {
+		if n != name {
+			continue
+		}
+		if prefix == "" {
+			return WeekdayNum{0, weekday}, nil
+		}
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -53 || ordinal > 53 {
+			return WeekdayNum{}, fmt.Errorf("bad ordinal")
+		}
+		return WeekdayNum{ordinal, weekday}, nil
+	}
+	return WeekdayNum{}, fmt.Errorf("bad weekday")
+}
+
+func (r RRule) String() string {
+	parts := []string{"FREQ=" + frequencyNames[r.Freq]}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	if len(r.ByMonth) > 0 {
+		months := []string{}
+		for _, month := range r.ByMonth {
+			months = append(months, strconv.Itoa(int(month)))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(months, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := []string{}
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByDay) > 0 {
+		days := []string{}
+		for _, day := range r.ByDay {
+			prefix := ""
+			if day.Ordinal != 0 {
+				prefix = strconv.Itoa(day.Ordinal)
+			}
+			days = append(days, prefix+weekdayNames[day.Weekday])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// PhasedByStart reports whether the rule's dates depend on its DTSTART,
+// through INTERVAL, COUNT or a day that DTSTART fills in.
+func (r RRule) PhasedByStart() bool {
+	if r.Interval > 1 || r.Count > 0 {
+		return true
+	}
+	switch r.Freq {
+	case FreqWeekly:
+		return len(r.ByDay) == 0
+	case FreqMonthly, FreqYearly:
+		return len(r.ByDay) == 0 && len(r.ByMonthDay) == 0
+	}
+	return false
+}
+
+// Between expands the rule starting at dtstart and returns the occurrances
+// that fall between from and to, inclusive. COUNT is counted from dtstart,
+// not from.
+func (r RRule) Between(dtstart, from, to time.Time) (occurrances []time.Time) {
+	year, month, day := dtstart.Date()
+	loc := dtstart.Location()
+	start := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	count := 0
+	for k := 0; ; k++ {
+		periodStart := r.periodStart(start, k*interval)
+		if periodStart.After(to) || (!r.Until.IsZero() && periodStart.After(r.Until)) {
+			return
+		}
+		for _, occ := range r.candidates(start, periodStart) {
+			if occ.Before(start) {
+				continue
+			}
+			if occ.After(to) || (!r.Until.IsZero() && occ.After(r.Until)) {
+				return
+			}
+			count++
+			if !occ.Before(from) {
+				occurrances = append(occurrances, occ)
+			}
+			if r.Count > 0 && count >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+func (r RRule) periodStart(start time.Time, n int) time.Time {
+	year, month, _ := start.Date()
+	loc := start.Location()
+	switch r.Freq {
+	case FreqDaily:
+		return start.AddDate(0, 0, n)
+	case FreqWeekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		return start.AddDate(0, 0, 7*n-offset)
+	case FreqMonthly:
+		return time.Date(year, month+time.Month(n), 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(year+n, time.January, 1, 0, 0, 0, 0, loc)
+	}
+}
+
+// candidates lists every date in the period starting at periodStart that
+// the BY* parts allow, in order.
+func (r RRule) candidates(start, periodStart time.Time) []time.Time {
+	var dates []time.Time
+	switch r.Freq {
+	case FreqDaily:
+		dates = []time.Time{periodStart}
+	case FreqWeekly:
+		if len(r.ByDay) == 0 {
+			dates = []time.Time{periodStart.AddDate(0, 0, (int(start.Weekday())-int(periodStart.Weekday())+7)%7)}
+		} else {
+			for i := 0; i < 7; i++ {
+				dates = append(dates, periodStart.AddDate(0, 0, i))
+			}
+		}
+	case FreqMonthly:
+		dates = r.monthCandidates(start, periodStart)
+	default:
+		months := r.ByMonth
+		if len(months) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			months = []time.Month{start.Month()}
+		}
+		if len(months) == 0 && len(r.ByMonthDay) == 0 {
+			dates = r.yearWeekdays(periodStart)
+		} else {
+			if len(months) == 0 {
+				for month := time.January; month <= time.December; month++ {
+					months = append(months, month)
+				}
+			}
+			for _, month := range months {
+				monthStart := time.Date(periodStart.Year(), month, 1, 0, 0, 0, 0, periodStart.Location())
+				dates = append(dates, r.monthCandidates(start, monthStart)...)
+			}
+		}
+	}
+
+	filtered := []time.Time{}
+	for _, date := range dates {
+		if r.allows(date) {
+			filtered = append(filtered, date)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Before(filtered[j]) })
+	return filtered
+}
+
+func (r RRule) monthCandidates(start, monthStart time.Time) []time.Time {
+	last := daysIn(monthStart.Year(), monthStart.Month())
+	var dates []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, day := range r.ByMonthDay {
+			if day < 0 {
+				day = last + 1 + day
+			}
+			if day >= 1 && day <= last {
+				dates = append(dates, monthStart.AddDate(0, 0, day-1))
+			}
+		}
+	case len(r.ByDay) > 0:
+		for _, wd := range r.ByDay {
+			var matches []time.Time
+			for day := 1; day <= last; day++ {
+				date := monthStart.AddDate(0, 0, day-1)
+				if date.Weekday() == wd.Weekday {
+					matches = append(matches, date)
+				}
+			}
+			dates = append(dates, pickOrdinal(matches, wd.Ordinal)...)
+		}
+	default:
+		if start.Day() <= last {
+			dates = append(dates, monthStart.AddDate(0, 0, start.Day()-1))
+		}
+	}
+	return dates
+}
+
+func (r RRule) yearWeekdays(yearStart time.Time) []time.Time {
+	var dates []time.Time
+	for _, wd := range r.ByDay {
+		var matches []time.Time
+		for date := yearStart; date.Year() == yearStart.Year(); date = date.AddDate(0, 0, 1) {
+			if date.Weekday() == wd.Weekday {
+				matches = append(matches, date)
+			}
+		}
+		dates = append(dates, pickOrdinal(matches, wd.Ordinal)...)
+	}
+	return dates
+}
+
+func pickOrdinal(matches []time.Time, ordinal int) []time.Time {
+	switch {
+	case ordinal == 0:
+		return matches
+	case ordinal > 0 && ordinal <= len(matches):
+		return matches[ordinal-1 : ordinal]
+	case ordinal < 0 && -ordinal <= len(matches):
+		return matches[len(matches)+ordinal : len(matches)+ordinal+1]
+	default:
+		return nil
+	}
+}
+
+// allows applies the BY* parts that only narrow the set, which is every
+// part that wasn't used to generate candidates for this frequency.
+func (r RRule) allows(date time.Time) bool {
+	if len(r.ByMonth) > 0 && !containsMonth(r.ByMonth, date.Month()) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq != FreqMonthly && r.Freq != FreqYearly {
+		last := daysIn(date.Year(), date.Month())
+		matched := false
+		for _, day := range r.ByMonthDay {
+			if day == date.Day() || last+1+day == date.Day() {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(r.ByDay) > 0 && (r.Freq == FreqDaily || r.Freq == FreqWeekly || len(r.ByMonthDay) > 0) {
+		matched := false
+		for _, wd := range r.ByDay {
+			if wd.Weekday == date.Weekday() {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,162 @@
+package Types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func dates(s ...string) []time.Time {
+	result := []time.Time{}
+	for _, d := range s {
+		result = append(result, date(d))
+	}
+	return result
+}
+
+func between(t *testing.T, rule string, dtstart, from, to string) []time.Time {
+	r, err := ParseRRule(rule)
+	assert.Nil(t, err)
+	return append([]time.Time{}, r.Between(date(dtstart), date(from), date(to))...)
+}
+
+func TestLastFridayOfTheMonth(t *testing.T) {
+	assert.Equal(t,
+		dates("2015.08.28", "2015.09.25", "2015.10.30", "2015.11.27"),
+		between(t, "FREQ=MONTHLY;BYDAY=-1FR", "2015.08.01", "2015.08.01", "2015.11.30"),
+	)
+}
+
+func TestEveryThirdWeek(t *testing.T) {
+	assert.Equal(t,
+		dates("2015.08.06", "2015.08.27", "2015.09.17"),
+		between(t, "FREQ=WEEKLY;INTERVAL=3", "2015.08.06", "2015.08.01", "2015.09.30"),
+	)
+}
+
+func TestEveryThirdWeekIsPhasedFromDTStart(t *testing.T) {
+	assert.Equal(t,
+		dates("2015.08.27", "2015.09.17"),
+		between(t, "FREQ=WEEKLY;INTERVAL=3", "2015.08.06", "2015.08.20", "2015.09.30"),
+	)
+}
+
+func TestLastDayOfMonth(t *testing.T) {
+	assert.Equal(t,
+		dates("2016.01.31", "2016.02.29", "2016.03.31", "2016.04.30"),
+		between(t, "RRULE:FREQ=MONTHLY;BYMONTHDAY=-1", "2016.01.01", "2016.01.01", "2016.04.30"),
+	)
+}
+
+func TestCount(t *testing.T) {
+	assert.Equal(t,
+		dates("2015.08.14", "2015.08.21"),
+		between(t, "FREQ=WEEKLY;BYDAY=FR;COUNT=3", "2015.08.01", "2015.08.10", "2015.12.31"),
+	)
+}
+
+func TestUntil(t *testing.T) {
+	assert.Equal(t,
+		dates("2015.08.03", "2015.08.05", "2015.08.07", "2015.08.10"),
+		between(t, "FREQ=WEEKLY;BYDAY=MO,WE,FR;UNTIL=20150810", "2015.08.01", "2015.08.01", "2015.12.31"),
+	)
+}
+
+func TestDailyWithFilters(t *testing.T) {
+	assert.Equal(t,
+		dates("2015.08.13", "2015.11.13"),
+		between(t, "FREQ=DAILY;BYMONTHDAY=13;BYDAY=TH,FR", "2015.01.01", "2015.08.01", "2015.12.31"),
+	)
+}
+
+func TestMonthlySkipsShortMonths(t *testing.T) {
+	assert.Equal(t,
+		dates("2015.01.31", "2015.03.31", "2015.05.31"),
+		between(t, "FREQ=MONTHLY", "2015.01.31", "2015.01.01", "2015.06.15"),
+	)
+}
+
+func TestYearly(t *testing.T) {
+	assert.Equal(t,
+		dates("2015.11.26", "2016.11.24"),
+		between(t, "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", "2015.01.01", "2015.01.01", "2016.12.31"),
+	)
+	assert.Equal(t,
+		dates("2015.12.25", "2016.12.25"),
+		between(t, "FREQ=YEARLY", "2014.12.25", "2015.01.01", "2016.12.31"),
+	)
+}
+
+func TestParseRRuleErrors(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=WEEKLY;INTERVAL=0",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1FR",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;COUNT=2;UNTIL=20151231",
+		"FREQ=MONTHLY;BYSETPOS=-1",
+		"FREQ=MONTHLY;FREQ=WEEKLY",
+	} {
+		_, err := ParseRRule(rule)
+		assert.Error(t, err, rule)
+	}
+}
+
+func TestRRuleStringRoundTrips(t *testing.T) {
+	for _, rule := range []string{
+		"FREQ=MONTHLY;BYDAY=-1FR",
+		"FREQ=WEEKLY;INTERVAL=3",
+		"FREQ=MONTHLY;COUNT=6;BYMONTHDAY=1,-1",
+		"FREQ=YEARLY;UNTIL=20201231;BYMONTH=11;BYDAY=4TH",
+		"FREQ=WEEKLY;BYDAY=MO,WE;WKST=SU",
+	} {
+		r, err := ParseRRule(rule)
+		assert.Nil(t, err)
+		assert.Equal(t, rule, r.String())
+	}
+}
+
+func TestPhasedByStart(t *testing.T) {
+	for rule, phased := range map[string]bool{
+		"FREQ=DAILY":                       false,
+		"FREQ=WEEKLY;BYDAY=MO,WE,FR":       false,
+		"FREQ=MONTHLY;BYDAY=-1FR":          false,
+		"FREQ=MONTHLY;BYMONTHDAY=-1":       false,
+		"FREQ=YEARLY;BYMONTH=11;BYDAY=4TH": false,
+		"FREQ=WEEKLY":                      true,
+		"FREQ=WEEKLY;INTERVAL=3;BYDAY=TH":  true,
+		"FREQ=MONTHLY":                     true,
+		"FREQ=MONTHLY;BYDAY=FR;COUNT=3":    true,
+		"FREQ=YEARLY;BYMONTH=4":            true,
+	} {
+		r, err := ParseRRule(rule)
+		assert.Nil(t, err)
+		assert.Equal(t, phased, r.PhasedByStart(), rule)
+	}
+}
+
+func TestRecurringSchedule(t *testing.T) {
+	s := Schedule{Period: Recurring, RRule: "FREQ=MONTHLY;BYDAY=-1FR"}
+	assert.Nil(t, s.Validate())
+	assert.Equal(t, dates("2015.08.28"), s.FindRealOccurrances(date("2015.08.01"), date("2015.08.31")))
+
+	s = Schedule{Period: Recurring, RRule: "FREQ=WEEKLY;INTERVAL=2", Time: date("2015.07.30")}
+	assert.Equal(t, dates("2015.08.13", "2015.08.27"), s.FindRealOccurrances(date("2015.08.01"), date("2015.08.31")))
+
+	// Every third week needs a week to count from.
+	s = Schedule{Period: Recurring, RRule: "FREQ=WEEKLY;INTERVAL=3"}
+	assert.Error(t, s.Validate())
+	assert.Empty(t, s.FindRealOccurrances(date("2015.08.01"), date("2015.08.31")))
+	s.Start = date("2015.08.06")
+	assert.Nil(t, s.Validate())
+	assert.Equal(t, dates("2015.08.27"), s.FindRealOccurrances(date("2015.08.20"), date("2015.08.31")))
+	assert.Equal(t, dates("2015.08.06", "2015.08.27"), s.FindRealOccurrances(date("2015.08.01"), date("2015.08.31")))
+
+	s = Schedule{Period: Recurring, RRule: "FREQ=SOMETIMES"}
+	assert.Error(t, s.Validate())
+	assert.Empty(t, s.FindRealOccurrances(date("2015.08.01"), date("2015.08.31")))
+}
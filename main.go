@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/n8downs/even_challenge/Types"
@@ -47,7 +49,16 @@ func main() {
 		},
 	}
 
-	plan, ideal := Plan(startDay, endDay, incomes, expenses)
+	options := Options{}
+	if formatter, err := money.LocaleFormatter(os.Getenv("LANG")); err == nil {
+		options.Formatter = formatter
+	}
+
+	plan, ideals, err := PlanWithIdeals(startDay, endDay, incomes, expenses, options)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 
 	if len(plan) == 0 {
 		fmt.Println("Insolvent :(")
@@ -55,45 +66,364 @@ func main() {
 
 	fmt.Println()
 
-	accounts, actual, err := Simulate(startDay, endDay, plan, true)
+	accounts, actual, err := SimulateWithOptions(startDay, endDay, plan, true, options)
 	if err != nil {
 		fmt.Println(err, accounts)
 	}
 
+	// The plan is budgeted on one ideal; the expected one is reported with it.
+	ideal := ideals.For(options.Planning)
 	fmt.Println()
-	fmt.Println("Ideal Average Spending", ideal.String(), "Actual Average Spending", actual.String())
-	fmt.Printf("Actual: %.2f%% of ideal\n", math.Abs(actual.Float()/ideal.Float()*100.))
+	fmt.Println("Ideal Average Spending", options.Formatter.Format(ideal), "Actual Average Spending", options.Formatter.Format(actual))
+	fmt.Println("Expected Average Spending", options.Formatter.Format(ideals.Expected))
+	if ratio, err := money.RateOf(actual.Abs(), ideal.Abs()); err == nil {
+		fmt.Printf("Actual: %s of ideal\n", ratio.StringFixed(2))
+	}
 	fmt.Println()
 }
 
-// Plan ...
+// Options ...
+type Options struct {
+	// Currency is the reporting currency that every income, expense and
+	// opening balance is converted into. When empty it is the currency of the
+	// first account that has one, then of the first income or expense amount
+	// that has one, and when none do amounts are used as they are.
+	Currency  money.Currency
+	Rates     *money.RateTable
+	Formatter money.Formatter
+
+	// SavingsAPR is the annual rate Simulate pays on the Savings balance,
+	// accrued daily and posted at the end of each month.
+	SavingsAPR money.Rate
+	DayCount   money.DayCount
+
+	Planning Planning
+
+	// Location is the zone of the dates in the ledger. Every date Plan and
+	// Simulate see is moved to midnight there on the day it is written as.
+	// When nil, startDay's zone is used.
+	Location *time.Location
+
+	// Accounts are the accounts Plan and Simulate use. When nil they are the
+	// Checking and Savings of Types.DefaultRegistry. Plan spends from the
+	// first checking account and saves into the first savings account, and
+	// counts the opening balances of those two.
+	Accounts *Types.Registry
+
+	// SpendFrom is the account discretionary spending goes through. When it
+	// is a credit account, Plan puts the spending on the card and pays each
+	// statement's spending from the spending account on its due date. An
+	// opening card balance is an expense paid off on the first statement's
+	// due date. When empty, spending comes straight out of the spending
+	// account.
+	SpendFrom Types.Account
+}
+
+func (o Options) registry() *Types.Registry {
+	if o.Accounts != nil {
+		return o.Accounts
+	}
+	return Types.DefaultRegistry()
+}
+
+// currency is the currency the plan is made in.
+func (o Options) currency() money.Currency {
+	if o.Currency != money.NoCurrency {
+		return o.Currency
+	}
+	for _, account := range o.registry().Accounts() {
+		if account.Currency != money.NoCurrency {
+			return account.Currency
+		}
+	}
+	return money.NoCurrency
+}
+
+// roles finds the accounts Plan spends from and saves into.
+func (o Options) roles() (spending, savings Types.Account, err error) {
+	registry := o.registry()
+	spending, ok := registry.First(Types.CheckingAccount)
+	if !ok {
+		return "", "", errors.New("no checking account to spend from")
+	}
+	savings, ok = registry.First(Types.SavingsAccount)
+	if !ok {
+		return "", "", errors.New("no savings account to save into")
+	}
+	return spending, savings, nil
+}
+
+// spendFrom finds the account Plan spends through, and its credit terms when
+// it is a card.
+func (o Options) spendFrom(spending Types.Account) (Types.Account, *Types.CreditTerms, error) {
+	if o.SpendFrom == "" || o.SpendFrom == spending {
+		return spending, nil, nil
+	}
+	account, ok := o.registry().Lookup(o.SpendFrom)
+	switch {
+	case !ok:
+		return "", nil, fmt.Errorf("unknown account %q", o.SpendFrom)
+	case account.Kind != Types.CreditAccount || account.Credit == nil:
+		return "", nil, fmt.Errorf("can't spend through %s: it isn't a credit account with credit terms", o.SpendFrom)
+	}
+	return account.Name, account.Credit, nil
+}
+
+// opening is an account's opening balance in the plan's currency.
+func (o Options) opening(name Types.Account, date time.Time) (money.Money, error) {
+	account, _ := o.registry().Lookup(name)
+	return o.convert(account.Opening, date)
+}
+
+// account resolves an income or expense account, defaulting to spending.
+func (o Options) account(name, spending Types.Account) (Types.Account, error) {
+	if name == "" {
+		return spending, nil
+	}
+	if _, ok := o.registry().Lookup(name); !ok {
+		return "", fmt.Errorf("unknown account %q", name)
+	}
+	return name, nil
+}
+
+func (o Options) civil(startDay time.Time) func(time.Time) time.Time {
+	loc := o.Location
+	if loc == nil {
+		loc = startDay.Location()
+	}
+	return func(date time.Time) time.Time {
+		return Types.CivilDate(date, loc)
+	}
+}
+
+// Planning ...
+type Planning int
+
+// Plannings ...
+const (
+	// PlanExpected budgets on every income paying its expected amount.
+	PlanExpected Planning = iota
+	// PlanConservative budgets on each income's Range minimum. The ledger
+	// still records the expected paycheck and moves anything above the
+	// minimum to savings.
+	PlanConservative
+)
+
+func (o Options) convert(m money.Money, date time.Time) (money.Money, error) {
+	currency := o.currency()
+	if currency == money.NoCurrency {
+		return m, nil
+	}
+	return o.Rates.Convert(m, currency, date)
+}
+
+// Plan is PlanWithOptions with the default Options.
 func Plan(
 	startDay time.Time,
 	endDay time.Time,
 	incomes []Types.Income,
 	expenses []Types.Expense,
-) (map[time.Time][]Types.Transaction, money.Money) {
+) (map[time.Time][]Types.Transaction, money.Money, error) {
+	return PlanWithOptions(startDay, endDay, incomes, expenses, Options{})
+}
+
+// PlanWithOptions returns the ideal daily spending the plan is budgeted on,
+// which is the worst case under PlanConservative.
+func PlanWithOptions(
+	startDay time.Time,
+	endDay time.Time,
+	incomes []Types.Income,
+	expenses []Types.Expense,
+	options Options,
+) (map[time.Time][]Types.Transaction, money.Money, error) {
+	ledger, ideals, err := PlanWithIdeals(startDay, endDay, incomes, expenses, options)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+	return ledger, ideals.For(options.Planning), nil
+}
+
+// Ideals are the ideal daily spending if every income pays its Range
+// minimum, its expected amount, or its Range maximum.
+type Ideals struct {
+	Min      money.Money
+	Expected money.Money
+	Max      money.Money
+}
+
+// For is the ideal a plan made with planning is budgeted on.
+func (i Ideals) For(planning Planning) money.Money {
+	if planning == PlanConservative {
+		return i.Min
+	}
+	return i.Expected
+}
+
+// PlanWithIdeals is PlanWithOptions reporting the ideal daily spending for
+// the worst, expected and best case, whichever the ledger is budgeted on.
+func PlanWithIdeals(
+	startDay time.Time,
+	endDay time.Time,
+	incomes []Types.Income,
+	expenses []Types.Expense,
+	options Options,
+) (map[time.Time][]Types.Transaction, Ideals, error) {
+	spending, savings, err := options.roles()
+	if err != nil {
+		return nil, Ideals{}, err
+	}
+	depositTo := make([]Types.Account, len(incomes))
+	for i, income := range incomes {
+		if err := income.Validate(); err != nil {
+			return nil, Ideals{}, fmt.Errorf("income %s: %w", income.Name, err)
+		}
+		if depositTo[i], err = options.account(income.Account, spending); err != nil {
+			return nil, Ideals{}, fmt.Errorf("income %s: %w", income.Name, err)
+		}
+	}
+	card, terms, err := options.spendFrom(spending)
+	if err != nil {
+		return nil, Ideals{}, err
+	}
+	payFrom := make([]Types.Account, len(expenses))
+	for i, expense := range expenses {
+		if err := expense.Schedule.Validate(); err != nil {
+			return nil, Ideals{}, fmt.Errorf("expense %s: %w", expense.Name, err)
+		}
+		if payFrom[i], err = options.account(expense.Account, spending); err != nil {
+			return nil, Ideals{}, fmt.Errorf("expense %s: %w", expense.Name, err)
+		}
+	}
+
+	civil := options.civil(startDay)
+	startDay, endDay = civil(startDay), civil(endDay)
+	if options.currency() == money.NoCurrency {
+		options.Currency = amountsCurrency(startDay, endDay, incomes, expenses)
+	}
+
 	ledger := map[time.Time][]Types.Transaction{}
 	totalIncome := money.New(0.)
+	// incomeRange totals the income in the worst, expected and best case.
+	incomeRange := [3]money.Money{money.New(0.), money.New(0.), money.New(0.)}
 	totalExpenses := money.New(0.)
 
 	incomeTotals := map[time.Time]money.Money{}
 	expenseTotals := map[time.Time]money.Money{}
 	savingsPlan := map[time.Time]money.Money{}
 
-	for _, income := range incomes {
-		occurrances := income.Schedule.FindRealOccurrances(startDay, endDay)
-		for _, date := range occurrances {
+	// Only cards may owe money in Simulate, so a plan can't start overdrawn.
+	for _, account := range options.registry().Accounts() {
+		if account.Kind != Types.CreditAccount && money.New(0.).GreaterThan(account.Opening) {
+			return nil, Ideals{}, fmt.Errorf("%s opens overdrawn at %s", account.Name, account.Opening)
+		}
+	}
+
+	// Money already in the spending account can be spent from startDay, as if
+	// it had been paid in that morning. Money already saved counts toward
+	// upcoming expenses.
+	openingSpending, err := options.opening(spending, startDay)
+	if err != nil {
+		return nil, Ideals{}, err
+	}
+	openingSavings, err := options.opening(savings, startDay)
+	if err != nil {
+		return nil, Ideals{}, err
+	}
+	if openingSpending.GreaterThan(money.New(0.)) {
+		totalIncome = openingSpending
+		incomeRange = [3]money.Money{openingSpending, openingSpending, openingSpending}
+		incomeTotals[startDay] = openingSpending
+		savingsPlan[startDay] = money.New(0.)
+	}
+
+	paychecks := make([][]paycheck, len(incomes))
+	for i, income := range incomes {
+		for _, occurrance := range income.FindRealOccurrances(startDay, endDay) {
+			date := civil(occurrance)
+			check := paycheck{date: date}
+			min, expected, max := income.RangeOn(occurrance)
+			for c, amount := range []money.Money{min, expected, max} {
+				if check.amounts[c], err = options.convert(amount, date); err != nil {
+					return nil, Ideals{}, err
+				}
+			}
+			check.budgeted = check.amounts[1]
+			if options.Planning == PlanConservative {
+				check.budgeted = check.amounts[0]
+			}
+			paychecks[i] = append(paychecks[i], check)
+		}
+	}
+
+	// expenseAmounts and fromSavings hold each occurrance's amount, and what
+	// of it savings covers, in the order FindRealOccurrances gives them.
+	expenseAmounts := make([]map[time.Time][]money.Money, len(expenses))
+	fromSavings := make([]map[time.Time][]money.Money, len(expenses))
+	for i, expense := range expenses {
+		expenseAmounts[i] = map[time.Time][]money.Money{}
+		fromSavings[i] = map[time.Time][]money.Money{}
+		for _, date := range expense.Schedule.FindRealOccurrances(startDay, endDay) {
+			amount, err := options.convert(expense.AmountOn(date), date)
+			if err != nil {
+				return nil, Ideals{}, err
+			}
+			expenseAmounts[i][civil(date)] = append(expenseAmounts[i][civil(date)], amount)
+			fromSavings[i][civil(date)] = append(fromSavings[i][civil(date)], amount)
+		}
+	}
+
+	for _, account := range options.registry().Accounts() {
+		if account.Name == spending || account.Name == savings {
+			continue
+		}
+		opening, err := options.opening(account.Name, startDay)
+		if err != nil {
+			return nil, Ideals{}, err
+		}
+		fundBills(account.Name, opening, startDay, endDay, depositTo, paychecks, payFrom, fromSavings)
+	}
+
+	for i, income := range incomes {
+		for _, check := range paychecks[i] {
+			date, amount := check.date, check.amounts[1]
 			ledger[date] = append(ledger[date], Types.Transaction{
 				Date:  date,
-				Delta: income.Amount,
+				Delta: amount,
 				Memo:  fmt.Sprintf("Income: %s", income.Name),
 				From:  Types.External,
-				To:    Types.Checking,
+				To:    depositTo[i],
 			})
-			totalIncome = totalIncome.Add(income.Amount)
-			incomeTotals[date] = incomeTotals[date].Add(income.Amount)
-			savingsPlan[date] = money.New(0.)
+			if swept := amount.Subtract(check.kept); depositTo[i] != spending && swept.GreaterThan(money.New(0.)) {
+				ledger[date] = append(ledger[date], Types.Transaction{
+					Date:  date,
+					Delta: swept.Multiply(-1.),
+					Memo:  fmt.Sprintf("Transfer to %s: %s", spending, income.Name),
+					From:  depositTo[i],
+					To:    spending,
+				})
+			}
+			if surplus := amount.Subtract(check.budgeted); surplus.GreaterThan(money.New(0.)) {
+				ledger[date] = append(ledger[date], Types.Transaction{
+					Date:  date,
+					Delta: surplus.Multiply(-1.),
+					Memo:  fmt.Sprintf("Transfer to %s: %s above minimum", savings, income.Name),
+					From:  spending,
+					To:    savings,
+				})
+			}
+			for c := range incomeRange {
+				if incomeRange[c], err = incomeRange[c].AddChecked(check.amounts[c].Subtract(check.kept)); err != nil {
+					return nil, Ideals{}, err
+				}
+			}
+			budgeted := check.budgeted.Subtract(check.kept)
+			if totalIncome, err = totalIncome.AddChecked(budgeted); err != nil {
+				return nil, Ideals{}, err
+			}
+			if check.kept.EqualTo(money.New(0.)) || !budgeted.EqualTo(money.New(0.)) {
+				incomeTotals[date] = incomeTotals[date].Add(budgeted)
+				savingsPlan[date] = money.New(0.)
+			}
 		}
 	}
 
@@ -106,23 +436,59 @@ func Plan(
 		firstIncomeDay = firstIncomeDay.AddDate(0, 0, 1)
 	}
 
-	for _, expense := range expenses {
-		occurrances := expense.FindVirtualOccurrances(firstIncomeDay, endDay)
+	for i, expense := range expenses {
+		amounts, next := fromSavings[i], map[time.Time]int{}
+		occurrances := expense.FindVirtualOccurrancesWith(firstIncomeDay, endDay, func(date time.Time) money.Money {
+			date = civil(date)
+			n := next[date]
+			next[date]++
+			if n >= len(amounts[date]) {
+				return money.Money{}
+			}
+			return amounts[date][n]
+		})
 		for date, amount := range occurrances {
-			totalExpenses = totalExpenses.Add(amount)
-			expenseTotals[date] = expenseTotals[date].Add(amount)
+			var err error
+			totalExpenses, err = totalExpenses.AddChecked(amount)
+			if err != nil {
+				return nil, Ideals{}, err
+			}
+			expenseTotals[civil(date)] = expenseTotals[civil(date)].Add(amount)
+		}
+	}
+
+	// What the card already owes is paid with its first statement.
+	cardOwed, firstDue := money.New(0.), time.Time{}
+	if terms != nil {
+		opening, err := options.opening(card, startDay)
+		if err != nil {
+			return nil, Ideals{}, err
+		}
+		closings := terms.Statement.FindRealOccurrances(startDay, endDay)
+		if owed := opening.Multiply(-1.); owed.GreaterThan(money.New(0.)) && len(closings) > 0 {
+			firstDue = terms.Due(civil(closings[0]))
+			if !firstDue.After(endDay) {
+				cardOwed = owed
+				if totalExpenses, err = totalExpenses.AddChecked(owed); err != nil {
+					return nil, Ideals{}, err
+				}
+				expenseTotals[firstDue] = expenseTotals[firstDue].Add(owed)
+			}
 		}
 	}
 
-	if totalExpenses.GreaterThan(totalIncome) {
-		return map[time.Time][]Types.Transaction{}, money.New(0.)
+	if totalExpenses.GreaterThan(totalIncome.Add(openingSavings)) {
+		return map[time.Time][]Types.Transaction{}, Ideals{money.New(0.), money.New(0.), money.New(0.)}, nil
 	}
 
-	discretionaryDivided := totalIncome.Subtract(totalExpenses).Divide(int64(endDay.Sub(startDay).Hours() / 24))
-	idealDiscretionary := discretionaryDivided[0]
+	ideal := func(income money.Money) money.Money {
+		return income.Add(openingSavings).Subtract(totalExpenses).Divide(int64(Types.DaysBetween(startDay, endDay)))[0]
+	}
+	ideals := Ideals{ideal(incomeRange[0]), ideal(incomeRange[1]), ideal(incomeRange[2])}
 
+	cardSpending := map[time.Time]money.Money{}
 	currentDate := startDay
-	runningSavings := money.New(0.)
+	runningSavings := openingSavings
 	for {
 		if currentDate.After(endDay) {
 			break
@@ -143,10 +509,10 @@ func Plan(
 				}
 			}
 
-			discretionaryDivided = totalIncome.Add(runningSavings).Subtract(totalExpenses).Divide(int64(math.Max(float64(endDay.Sub(currentDate).Hours()/24), 1.)))
+			discretionaryDivided := totalIncome.Add(runningSavings).Subtract(totalExpenses).Divide(int64(math.Max(float64(Types.DaysBetween(currentDate, endDay)), 1.)))
 			currentIdeal := discretionaryDivided[0]
 
-			daysUntilNextIncome := int64(nextIncomeDate.Sub(currentDate).Hours() / 24)
+			daysUntilNextIncome := int64(Types.DaysBetween(currentDate, nextIncomeDate))
 			mustTransfer := upcomingExpenses.Subtract(runningSavings)
 			idealTransfer := incomeTotals[currentDate].Subtract(currentIdeal.Multiply(float64(daysUntilNextIncome)))
 			transfer := money.Max(mustTransfer, idealTransfer)
@@ -154,15 +520,16 @@ func Plan(
 
 			runningSavings = runningSavings.Add(transfer).Subtract(upcomingExpenses)
 			savingsPlan[currentDate] = transfer.Multiply(-1.)
-			actualDiscretionaries := incomeTotals[currentDate].Subtract(transfer).Divide(daysUntilNextIncome)
+			actualDiscretionaries := incomeTotals[currentDate].Subtract(transfer).DivideEvenly(daysUntilNextIncome, money.SpreadFront)
 
 			totalIncome = totalIncome.Subtract(incomeTotals[currentDate])
 			totalExpenses = totalExpenses.Subtract(upcomingExpenses)
 
 			simulatedSpendingDate := currentDate
 			for _, discretionaryAmount := range actualDiscretionaries {
+				cardSpending[simulatedSpendingDate] = cardSpending[simulatedSpendingDate].Add(discretionaryAmount)
 				ledger[simulatedSpendingDate] = append(ledger[simulatedSpendingDate], Types.Transaction{
-					From:  Types.Checking,
+					From:  card,
 					To:    Types.External,
 					Memo:  simulatedSpendingMemo,
 					Date:  simulatedSpendingDate,
@@ -179,43 +546,191 @@ func Plan(
 			ledger[date] = append(ledger[date], Types.Transaction{
 				Date:  date,
 				Delta: amount,
-				Memo:  "Transfer to Savings",
-				From:  Types.Checking,
-				To:    Types.Savings,
+				Memo:  fmt.Sprintf("Transfer to %s", savings),
+				From:  spending,
+				To:    savings,
 			})
 		} else {
 			ledger[date] = append(ledger[date], Types.Transaction{
 				Date:  date,
 				Delta: amount,
-				Memo:  "Transfer from Savings",
-				From:  Types.Savings,
-				To:    Types.Checking,
+				Memo:  fmt.Sprintf("Transfer from %s", savings),
+				From:  savings,
+				To:    spending,
 			})
 		}
 	}
 
-	for _, expense := range expenses {
+	for i, expense := range expenses {
 		occurrances := expense.Schedule.FindRealOccurrances(startDay, endDay)
-		for _, date := range occurrances {
-			ledger[date] = append(ledger[date],
-				Types.Transaction{
+		next := map[time.Time]int{}
+		for _, occurrance := range occurrances {
+			date := civil(occurrance)
+			n := next[date]
+			next[date]++
+			amount, covered := expenseAmounts[i][date][n], fromSavings[i][date][n]
+			// An expense paid from savings comes straight out of it.
+			if payFrom[i] != savings && (payFrom[i] == spending || covered.GreaterThan(money.New(0.))) {
+				ledger[date] = append(ledger[date], Types.Transaction{
 					Date:  date,
-					Delta: expense.Amount,
-					Memo:  fmt.Sprintf("Transfer from Savings for: %s", expense.Name),
-					From:  Types.Savings,
-					To:    Types.Checking,
-				},
+					Delta: covered,
+					Memo:  fmt.Sprintf("Transfer from %s for: %s", savings, expense.Name),
+					From:  savings,
+					To:    payFrom[i],
+				})
+			}
+			ledger[date] = append(ledger[date],
 				Types.Transaction{
 					Date:  date,
-					Delta: expense.Amount.Multiply(-1.),
+					Delta: amount.Multiply(-1.),
 					Memo:  fmt.Sprintf("Expense: %s", expense.Name),
-					From:  Types.Checking,
+					From:  payFrom[i],
 					To:    Types.External,
 				},
 			)
 		}
 	}
-	return ledger, idealDiscretionary
+
+	// Each statement's spending is paid in full on its due date, out of the
+	// allowances left in the spending account.
+	if terms != nil {
+		if cardOwed.GreaterThan(money.New(0.)) {
+			ledger[firstDue] = append(ledger[firstDue],
+				Types.Transaction{
+					Date:  firstDue,
+					Delta: cardOwed,
+					Memo:  fmt.Sprintf("Transfer from %s for: %s opening balance", savings, card),
+					From:  savings,
+					To:    spending,
+				},
+				Types.Transaction{
+					Date:  firstDue,
+					Delta: cardOwed.Multiply(-1.),
+					Memo:  fmt.Sprintf("Expense: %s opening balance", card),
+					From:  spending,
+					To:    card,
+				},
+			)
+		}
+		cycleStart := startDay
+		for _, closing := range terms.Statement.FindRealOccurrances(startDay, endDay) {
+			closing = civil(closing)
+			balance := money.New(0.)
+			for date := cycleStart; !date.After(closing); date = date.AddDate(0, 0, 1) {
+				balance = balance.Add(cardSpending[date])
+			}
+			cycleStart = closing.AddDate(0, 0, 1)
+
+			due := terms.Due(closing)
+			if due.After(endDay) || balance.EqualTo(money.New(0.)) {
+				continue
+			}
+			ledger[due] = append(ledger[due], Types.Transaction{
+				Date:  due,
+				Delta: balance.Multiply(-1.),
+				Memo:  fmt.Sprintf("Expense: %s payment", card),
+				From:  spending,
+				To:    card,
+			})
+		}
+	}
+	return ledger, ideals, nil
+}
+
+// amountsCurrency is the currency of the first income or expense amount that
+// has one. A plan with no reporting currency is made in it, so that amounts in
+// other currencies are converted rather than mixed.
+func amountsCurrency(startDay, endDay time.Time, incomes []Types.Income, expenses []Types.Expense) money.Currency {
+	amounts := []money.Money{}
+	for _, income := range incomes {
+		for _, date := range income.FindRealOccurrances(startDay, endDay) {
+			min, expected, max := income.RangeOn(date)
+			amounts = append(amounts, expected, min, max)
+		}
+	}
+	for _, expense := range expenses {
+		for _, date := range expense.Schedule.FindRealOccurrances(startDay, endDay) {
+			amounts = append(amounts, expense.AmountOn(date))
+		}
+	}
+	for _, amount := range amounts {
+		if amount.Currency() != money.NoCurrency {
+			return amount.Currency()
+		}
+	}
+	return money.NoCurrency
+}
+
+// paycheck is one income occurrance: its least, expected and most amount,
+// what the plan budgets on, and what a bills account keeps of it.
+type paycheck struct {
+	date     time.Time
+	amounts  [3]money.Money
+	budgeted money.Money
+	kept     money.Money
+}
+
+// fundBills pays the expenses of a bills account, one that is neither spent
+// from nor saved into, out of its opening balance and what is deposited
+// there. Each deposit keeps what the account still needs until its next
+// deposit, up to the amount budgeted, and the rest moves to spending. What
+// the account can't cover is left in fromSavings.
+func fundBills(
+	account Types.Account,
+	opening money.Money,
+	startDay time.Time,
+	endDay time.Time,
+	depositTo []Types.Account,
+	paychecks [][]paycheck,
+	payFrom []Types.Account,
+	fromSavings []map[time.Time][]money.Money,
+) {
+	deposits := map[time.Time][]*paycheck{}
+	for i := range paychecks {
+		if depositTo[i] != account {
+			continue
+		}
+		for k := range paychecks[i] {
+			check := &paychecks[i][k]
+			deposits[check.date] = append(deposits[check.date], check)
+		}
+	}
+	due := map[time.Time]money.Money{}
+	for i := range payFrom {
+		if payFrom[i] != account {
+			continue
+		}
+		for date, amounts := range fromSavings[i] {
+			for _, amount := range amounts {
+				due[date] = due[date].Add(amount)
+			}
+		}
+	}
+
+	balance := money.Max(opening, money.New(0.))
+	for date := startDay; !date.After(endDay); date = date.AddDate(0, 0, 1) {
+		for _, check := range deposits[date] {
+			needed := money.New(0.)
+			for day := date; !day.After(endDay); day = day.AddDate(0, 0, 1) {
+				if day.After(date) && len(deposits[day]) > 0 {
+					break
+				}
+				needed = needed.Add(due[day])
+			}
+			check.kept = money.Min(money.Max(needed.Subtract(balance), money.New(0.)), check.budgeted)
+			balance = balance.Add(check.kept)
+		}
+		for i := range payFrom {
+			if payFrom[i] != account {
+				continue
+			}
+			for n, amount := range fromSavings[i][date] {
+				covered := money.Min(balance, amount)
+				balance = balance.Subtract(covered)
+				fromSavings[i][date][n] = amount.Subtract(covered)
+			}
+		}
+	}
 }
 
 // Simulate ...
@@ -225,18 +740,117 @@ func Simulate(
 	ledger map[time.Time][]Types.Transaction,
 	shouldPrintOutput bool,
 ) (accounts map[Types.Account]money.Money, averageSpending money.Money, err error) {
+	return SimulateWithOptions(startDay, endDay, ledger, shouldPrintOutput, Options{})
+}
+
+// SimulateWithOptions ...
+func SimulateWithOptions(
+	startDay time.Time,
+	endDay time.Time,
+	ledger map[time.Time][]Types.Transaction,
+	shouldPrintOutput bool,
+	options Options,
+) (accounts map[Types.Account]money.Money, averageSpending money.Money, err error) {
+	civil := options.civil(startDay)
+	startDay, endDay = civil(startDay), civil(endDay)
+	format := options.Formatter.Format
 	simulatedSpending := money.New(0.)
 	numDays := int64(0)
+	registered := options.registry().Accounts()
 	accounts = map[Types.Account]money.Money{
 		Types.External: money.New(0.),
-		Types.Checking: money.New(0.),
-		Types.Savings:  money.New(0.),
+	}
+	for _, account := range registered {
+		if accounts[account.Name], err = options.opening(account.Name, startDay); err != nil {
+			return accounts, money.New(0.), err
+		}
+	}
+
+	// Each registered account gets a column, at least 9 wide.
+	width := func(name Types.Account) int {
+		return int(math.Max(9, float64(len(name))))
+	}
+	balances := func() string {
+		columns := ""
+		for _, account := range registered {
+			columns += fmt.Sprintf(" | %*s", width(account.Name), format(accounts[account.Name]))
+		}
+		return columns
 	}
 
 	if shouldPrintOutput {
-		fmt.Printf("%-10s | %-40s | %-15s | %9s | %9s\n", "Date", "Transaction", "Amount(from Ck)", "Checking", "Savings")
-		fmt.Println("-----------------------------------------------------------------------------------------------")
-		fmt.Printf("%10s | %-40s | %-15s | %9s | %9s\n", startDay.Format(Types.DateFormat), "<Initial balances>", "", accounts[Types.Checking], accounts[Types.Savings])
+		header, rule := "", 71
+		for _, account := range registered {
+			header += fmt.Sprintf(" | %*s", width(account.Name), account.Name)
+			rule += 3 + width(account.Name)
+		}
+		fmt.Printf("%-10s | %-40s | %-15s%s\n", "Date", "Transaction", "Amount(from Ck)", header)
+		fmt.Println(strings.Repeat("-", rule))
+		fmt.Printf("%10s | %-40s | %-15s%s\n", startDay.Format(Types.DateFormat), "<Initial balances>", "", balances())
+	}
+
+	apply := func(transaction Types.Transaction) error {
+		if transaction.From == transaction.To {
+			return fmt.Errorf("%s: can't transfer from %s to itself", transaction.Memo, transaction.From)
+		}
+		from, err := accounts[transaction.From].SubtractChecked(transaction.Delta.Abs())
+		if err != nil {
+			return err
+		}
+		to, err := accounts[transaction.To].AddChecked(transaction.Delta.Abs())
+		if err != nil {
+			return err
+		}
+		accounts[transaction.From] = from
+		accounts[transaction.To] = to
+
+		if shouldPrintOutput {
+			fmt.Printf("%s%s\n", transaction.Format(options.Formatter), balances())
+		}
+
+		// Credit accounts are the only ones allowed to owe money.
+		for _, account := range registered {
+			if account.Kind != Types.CreditAccount && money.New(0.).GreaterThan(accounts[account.Name]) {
+				return errors.New("Balance dipped below zero!")
+			}
+		}
+		return nil
+	}
+	savingsInterest := map[Types.Account]*money.Accrual{}
+	for _, account := range registered {
+		if account.Kind == Types.SavingsAccount {
+			savingsInterest[account.Name] = &money.Accrual{APR: options.SavingsAPR, DayCount: options.DayCount}
+		}
+	}
+
+	// A card's statement balance is what was owed when it last closed. Once a
+	// statement is left partly unpaid past its due date, the card carries its
+	// balance and accrues interest until a statement is paid in full on time.
+	type statement struct {
+		terms    *Types.CreditTerms
+		closings map[time.Time]bool
+		balance  money.Money
+		paid     money.Money
+		due      time.Time
+		carrying bool
+		interest *money.Accrual
+	}
+	cards := map[Types.Account]*statement{}
+	for _, account := range registered {
+		if account.Kind != Types.CreditAccount || account.Credit == nil {
+			continue
+		}
+		card := &statement{
+			terms:    account.Credit,
+			closings: map[time.Time]bool{},
+			balance:  money.New(0.),
+			paid:     money.New(0.),
+			interest: &money.Accrual{APR: account.Credit.APR, DayCount: account.Credit.DayCount},
+		}
+		for _, closing := range account.Credit.Statement.FindRealOccurrances(startDay, endDay) {
+			card.closings[civil(closing)] = true
+		}
+		cards[account.Name] = card
 	}
 
 	currentDate := startDay
@@ -248,34 +862,84 @@ func Simulate(
 		transactions := ledger[currentDate]
 		if len(transactions) == 0 && shouldPrintOutput {
 			fmt.Printf(
-				"%s | %-40s |                 | %9s | %9s\n",
+				"%s | %-40s |                %s\n",
 				currentDate.Format(Types.DateFormat),
 				"  (Nothing to spend)",
-				accounts[Types.Checking].String(),
-				accounts[Types.Savings].String(),
+				balances(),
 			)
 		}
 		for _, transaction := range transactions {
 			if transaction.Memo == simulatedSpendingMemo {
 				simulatedSpending = simulatedSpending.Add(transaction.Delta)
 			}
-			accounts[transaction.From] = accounts[transaction.From].Subtract(transaction.Delta.Abs())
-			accounts[transaction.To] = accounts[transaction.To].Add(transaction.Delta.Abs())
-
-			if shouldPrintOutput {
-				fmt.Printf("%s | %9s | %9s\n",
-					transaction.String(),
-					accounts[Types.Checking].String(),
-					accounts[Types.Savings].String(),
-				)
+			if err := apply(transaction); err != nil {
+				return accounts, money.New(0.), err
 			}
+			if card := cards[transaction.To]; card != nil {
+				card.paid = card.paid.Add(transaction.Delta.Abs())
+			}
+		}
 
-			if money.New(0.).GreaterThan(accounts[Types.Checking]) || money.New(0.).GreaterThan(accounts[Types.Savings]) {
-				return accounts, money.New(0.), errors.New("Balance dipped below zero!")
+		nextDate := currentDate.AddDate(0, 0, 1)
+		for _, account := range registered {
+			card := cards[account.Name]
+			if card == nil {
+				continue
+			}
+			if currentDate.Equal(card.due) {
+				card.carrying = card.balance.GreaterThan(card.paid)
+			}
+			owed := func() money.Money {
+				return money.Max(accounts[account.Name].Multiply(-1.), money.New(0.))
+			}
+			if card.carrying {
+				card.interest.Accrue(owed(), currentDate, nextDate)
+			}
+			closing := card.closings[currentDate]
+			if closing || currentDate.Equal(endDay) {
+				interest := card.interest.Post(money.HalfEven)
+				if !interest.EqualTo(money.New(0.)) {
+					err := apply(Types.Transaction{
+						Date:  currentDate,
+						Delta: interest.Multiply(-1.),
+						Memo:  "Interest",
+						From:  account.Name,
+						To:    Types.External,
+					})
+					if err != nil {
+						return accounts, money.New(0.), err
+					}
+				}
+			}
+			if closing {
+				card.balance, card.paid = owed(), money.New(0.)
+				card.due = card.terms.Due(currentDate)
+			}
+		}
+		for _, account := range registered {
+			accrual := savingsInterest[account.Name]
+			if accrual == nil || options.SavingsAPR.IsZero() {
+				continue
+			}
+			accrual.Accrue(accounts[account.Name], currentDate, nextDate)
+			if nextDate.Day() == 1 || currentDate.Equal(endDay) {
+				interest := accrual.Post(money.HalfEven)
+				if !interest.EqualTo(money.New(0.)) {
+					err := apply(Types.Transaction{
+						Date:  currentDate,
+						Delta: interest,
+						Memo:  "Interest",
+						From:  Types.External,
+						To:    account.Name,
+					})
+					if err != nil {
+						return accounts, money.New(0.), err
+					}
+				}
 			}
 		}
 		numDays += 1.
-		currentDate = currentDate.AddDate(0, 0, 1)
+		currentDate = nextDate
 	}
 
 	divided := simulatedSpending.Divide(numDays)
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"io"
+	"math"
+	"os"
+	"sort"
 	"testing"
 	"time"
 
@@ -44,7 +48,8 @@ func TestBasicHappyPath(t *testing.T) {
 		},
 	}
 
-	plan, idealSpending := Plan(startDay, endDay, incomes, expenses)
+	plan, idealSpending, err := Plan(startDay, endDay, incomes, expenses)
+	assert.Equal(t, nil, err)
 	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
 	assert.Equal(t, nil, err)
 
@@ -85,7 +90,8 @@ func TestInsolvent(t *testing.T) {
 		},
 	}
 
-	plan, idealSpending := Plan(startDay, endDay, incomes, expenses)
+	plan, idealSpending, err := Plan(startDay, endDay, incomes, expenses)
+	assert.Equal(t, nil, err)
 	assert.Equal(t, 0, len(plan))
 	assert.Equal(t, money.New(0.), idealSpending)
 }
@@ -126,7 +132,8 @@ func TestOneTimePayment(t *testing.T) {
 		},
 	}
 
-	plan, idealSpending := Plan(startDay, endDay, incomes, expenses)
+	plan, idealSpending, err := Plan(startDay, endDay, incomes, expenses)
+	assert.Equal(t, nil, err)
 	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
 	assert.Equal(t, nil, err)
 
@@ -173,7 +180,8 @@ func TestLongTimeWindow(t *testing.T) {
 		},
 	}
 
-	plan, idealSpending := Plan(startDay, endDay, incomes, expenses)
+	plan, idealSpending, err := Plan(startDay, endDay, incomes, expenses)
+	assert.Equal(t, nil, err)
 	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
 	assert.Equal(t, nil, err)
 
@@ -214,7 +222,8 @@ func TestIncomeHappensOnEndDayOfPlan(t *testing.T) {
 		},
 	}
 
-	plan, idealSpending := Plan(startDay, endDay, incomes, expenses)
+	plan, idealSpending, err := Plan(startDay, endDay, incomes, expenses)
+	assert.Equal(t, nil, err)
 	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
 	assert.Equal(t, nil, err)
 
@@ -262,7 +271,8 @@ func TestExpensesOutsideThePlanWindow(t *testing.T) {
 		},
 	}
 
-	plan, idealSpending := Plan(startDay, endDay, incomes, expenses)
+	plan, idealSpending, err := Plan(startDay, endDay, incomes, expenses)
+	assert.Equal(t, nil, err)
 	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
 	assert.Equal(t, nil, err)
 
@@ -299,7 +309,8 @@ func TestWeeklyIncomeWithCloseOneOff(t *testing.T) {
 		},
 	}
 
-	plan, idealSpending := Plan(startDay, endDay, incomes, expenses)
+	plan, idealSpending, err := Plan(startDay, endDay, incomes, expenses)
+	assert.Equal(t, nil, err)
 	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
 	assert.Equal(t, nil, err)
 
@@ -309,3 +320,988 @@ func TestWeeklyIncomeWithCloseOneOff(t *testing.T) {
 
 	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
 }
+
+func TestReportingCurrency(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	rates := money.NewRateTable()
+	rates.Set(money.GBP, money.USD, startDay, "1.5")
+	rates.Set(money.GBP, money.USD, startDay.AddDate(0, 0, 14), "1.6")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.NewIn(500., money.GBP),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.NewIn(400., money.USD),
+			Name:     "Rent",
+			Schedule: Types.Schedule{Period: Types.Monthly, Date: 28},
+		},
+	}
+
+	options := Options{Currency: money.USD, Rates: rates}
+	plan, idealSpending, err := PlanWithOptions(startDay, endDay, incomes, expenses, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.NewIn(750., money.USD), plan[startDay][0].Delta)
+	assert.Equal(t, money.NewIn(800., money.USD), plan[startDay.AddDate(0, 0, 14)][0].Delta)
+
+	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
+	assert.Equal(t, nil, err)
+
+	assert.True(t, accounts[Types.Checking].EqualTo(money.New(0.)))
+	assert.True(t, accounts[Types.Savings].EqualTo(money.New(0.)))
+
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+}
+
+func TestMissingExchangeRate(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.NewIn(500., money.EUR),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+	}
+
+	_, _, err := PlanWithOptions(startDay, endDay, incomes, []Types.Expense{}, Options{Currency: money.USD})
+	assert.ErrorIs(t, err, money.ErrNoRate)
+}
+
+func TestMixedCurrenciesWithoutReportingCurrency(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.NewIn(500., money.GBP),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.NewIn(400., money.USD),
+			Name:     "Rent",
+			Schedule: Types.Schedule{Period: Types.Monthly, Date: 28},
+		},
+	}
+
+	_, _, err := Plan(startDay, endDay, incomes, expenses)
+	assert.ErrorIs(t, err, money.ErrNoRate)
+
+	// With rates, the plan is made in the currency of the first income.
+	rates := money.NewRateTable()
+	assert.Nil(t, rates.Set(money.GBP, money.USD, startDay, "1.6"))
+	options := Options{Rates: rates}
+	plan, idealSpending, err := PlanWithOptions(startDay, endDay, incomes, expenses, options)
+	assert.Equal(t, nil, err)
+	for _, transaction := range plan[startDay.AddDate(0, 0, 27)] {
+		if transaction.Memo == "Expense: Rent" {
+			assert.Equal(t, money.NewIn(-250., money.GBP), transaction.Delta)
+		}
+	}
+
+	accounts, avgSimulatedSpending, err := SimulateWithOptions(startDay, endDay, plan, false, options)
+	assert.Equal(t, nil, err)
+	assert.True(t, accounts[Types.Checking].EqualTo(money.New(0.)))
+	assert.True(t, accounts[Types.Savings].EqualTo(money.New(0.)))
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+}
+
+func TestPlanOverflow(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.FromMinorUnits(math.MaxInt64 / 2),
+			Name:     "Lottery",
+			Schedule: Types.Schedule{Period: Types.Weekly, Weekday: time.Friday},
+		},
+	}
+
+	_, _, err := PlanWithOptions(startDay, endDay, incomes, []Types.Expense{}, Options{})
+	assert.ErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestSavingsInterest(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.10.31")
+
+	ledger := map[time.Time][]Types.Transaction{
+		startDay: []Types.Transaction{
+			Types.Transaction{
+				Date:  startDay,
+				Delta: money.New(-3650.),
+				Memo:  "Transfer to Savings",
+				From:  Types.External,
+				To:    Types.Savings,
+			},
+		},
+	}
+
+	options := Options{SavingsAPR: money.Percent(1), DayCount: money.Actual365}
+	accounts, _, err := SimulateWithOptions(startDay, endDay, ledger, false, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(3659.21), accounts[Types.Savings])
+	assert.Equal(t, money.New(-3659.21), accounts[Types.External])
+}
+
+func TestRecurringExpense(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.10.31")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.New(120.),
+			Name:     "Storage Unit",
+			Schedule: Types.Schedule{Period: Types.Recurring, RRule: "FREQ=MONTHLY;BYDAY=-1FR"},
+		},
+	}
+
+	plan, idealSpending, err := PlanWithOptions(startDay, endDay, incomes, expenses, Options{})
+	assert.Equal(t, nil, err)
+	lastFriday, _ := time.Parse(Types.DateFormat, "2015.09.25")
+	assert.Equal(t, "Expense: Storage Unit", plan[lastFriday][len(plan[lastFriday])-1].Memo)
+
+	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, money.New(0.), accounts[Types.Checking])
+	assert.Equal(t, money.New(0.), accounts[Types.Savings])
+
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+}
+
+func TestAnchoredPaychecksDoNotDependOnStartDay(t *testing.T) {
+	endDay, _ := time.Parse(Types.DateFormat, "2015.09.30")
+	anchor, _ := time.Parse(Types.DateFormat, "2015.07.30")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(175.),
+			Name:     "Mission Cliffs",
+			Schedule: Types.Schedule{Period: Types.BiWeekly, Anchor: anchor},
+		},
+	}
+
+	paydays := func(start string) []time.Time {
+		startDay, _ := time.Parse(Types.DateFormat, start)
+		plan, _, err := Plan(startDay, endDay, incomes, []Types.Expense{})
+		assert.Equal(t, nil, err)
+		dates := []time.Time{}
+		for date, transactions := range plan {
+			for _, transaction := range transactions {
+				if transaction.Memo == "Income: Mission Cliffs" && date.Month() == time.September {
+					dates = append(dates, date)
+				}
+			}
+		}
+		sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+		return dates
+	}
+
+	assert.Equal(t, paydays("2015.08.01"), paydays("2015.08.07"))
+	assert.Equal(t, 2, len(paydays("2015.08.01")))
+}
+
+func TestEveryNDaysExpenseWhenIncomeStartsLate(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+	anchor, _ := time.Parse(Types.DateFormat, "2015.07.28")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(600.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly, Days: []int{5, 20}},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.New(100.),
+			Name:     "Dog Walker",
+			Schedule: Types.Schedule{Period: Types.EveryNDays, Interval: 10, Anchor: anchor},
+		},
+	}
+
+	plan, idealSpending, err := PlanWithOptions(startDay, endDay, incomes, expenses, Options{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(plan[startDay]))
+
+	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts[Types.Savings])
+	walks := money.New(0.)
+	for _, transactions := range plan {
+		for _, transaction := range transactions {
+			if transaction.Memo == "Expense: Dog Walker" {
+				walks = walks.Add(transaction.Delta)
+			}
+		}
+	}
+	assert.Equal(t, money.New(-300.), walks)
+	assert.True(t, avgSimulatedSpending.GreaterThan(money.New(0.)))
+	assert.True(t, idealSpending.GreaterThan(money.New(0.)))
+
+	// Unanchored, the days would depend on whether the plan or its budget
+	// asked about them.
+	expenses[0].Schedule.Anchor = time.Time{}
+	_, _, err = PlanWithOptions(startDay, endDay, incomes, expenses, Options{})
+	assert.Error(t, err)
+}
+
+func TestAnnualExpenseIsSmoothed(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.10.31")
+	renewal, _ := time.Parse(Types.DateFormat, "2014.10.20")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.New(900.),
+			Name:     "Car Insurance",
+			Schedule: Types.Schedule{Period: Types.Annually, Anchor: renewal},
+		},
+	}
+
+	plan, idealSpending, err := Plan(startDay, endDay, incomes, expenses)
+	assert.Equal(t, nil, err)
+	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, money.New(0.), accounts[Types.Checking])
+	assert.Equal(t, money.New(0.), accounts[Types.Savings])
+
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+}
+
+func TestMembershipEndsAndAmountChanges(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+	lastClass, _ := time.Parse(Types.DateFormat, "2015.08.18")
+	bigClass, _ := time.Parse(Types.DateFormat, "2015.08.11")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount: money.New(40.),
+			Name:   "Crossfit",
+			Schedule: Types.Schedule{
+				Period:    Types.Weekly,
+				Weekday:   time.Tuesday,
+				End:       lastClass,
+				Overrides: []Types.Override{{Date: bigClass, Amount: money.New(65.)}},
+			},
+		},
+	}
+
+	plan, _, err := Plan(startDay, endDay, incomes, expenses)
+	assert.Equal(t, nil, err)
+	spent := money.New(0.)
+	classes := 0
+	for _, transactions := range plan {
+		for _, transaction := range transactions {
+			if transaction.Memo == "Expense: Crossfit" {
+				spent = spent.Add(transaction.Delta)
+				classes++
+			}
+		}
+	}
+	assert.Equal(t, 3, classes)
+	assert.Equal(t, money.New(-145.), spent)
+
+	accounts, _, err := Simulate(startDay, endDay, plan, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts[Types.Checking])
+	assert.Equal(t, money.New(0.), accounts[Types.Savings])
+}
+
+func TestMovedRentIsStillPaid(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.09.30")
+	august, _ := time.Parse(Types.DateFormat, "2015.08.28")
+	september, _ := time.Parse(Types.DateFormat, "2015.09.28")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount: money.New(400.),
+			Name:   "Rent",
+			Schedule: Types.Schedule{
+				Period:    Types.Monthly,
+				Date:      28,
+				Overrides: []Types.Override{{Date: august, MoveTo: september}},
+			},
+		},
+	}
+
+	plan, _, err := PlanWithOptions(startDay, endDay, incomes, expenses, Options{})
+	assert.Equal(t, nil, err)
+	rent := money.New(0.)
+	for _, transaction := range plan[september] {
+		if transaction.Memo == "Expense: Rent" {
+			rent = rent.Add(transaction.Delta)
+		}
+	}
+	assert.Equal(t, money.New(-800.), rent)
+
+	_, _, err = Simulate(startDay, endDay, plan, false)
+	assert.Equal(t, nil, err)
+}
+
+func TestRaiseMidPlan(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.09.30")
+	raise, _ := time.Parse(Types.DateFormat, "2015.09.01")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:    money.New(500.),
+			Name:      "Philz",
+			Schedule:  Types.Schedule{Period: Types.BiMonthly},
+			Variation: Types.Variation{Steps: []Types.Step{{From: raise, Amount: money.New(550.)}}},
+		},
+	}
+
+	plan, _, err := Plan(startDay, endDay, incomes, []Types.Expense{})
+	assert.Equal(t, nil, err)
+	earned := money.New(0.)
+	for _, transactions := range plan {
+		for _, transaction := range transactions {
+			if transaction.Memo == "Income: Philz" {
+				earned = earned.Add(transaction.Delta)
+			}
+		}
+	}
+	assert.Equal(t, money.New(2100.), earned)
+
+	accounts, _, err := Simulate(startDay, endDay, plan, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts[Types.Checking])
+}
+
+func TestHourlyIncome(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+	firstPeriod, _ := time.Parse(Types.DateFormat, "2015.07.27")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Name: "Philz",
+			Hourly: Types.Hourly{
+				Rate:           money.New(15.50),
+				ShiftLength:    6 * time.Hour,
+				Shifts:         Types.Schedule{Period: Types.Recurring, RRule: "FREQ=WEEKLY;BYDAY=MO,WE,FR"},
+				PayPeriodStart: firstPeriod,
+				PaydayOffset:   5,
+			},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.New(400.),
+			Name:     "Rent",
+			Schedule: Types.Schedule{Period: Types.Monthly, Date: 28},
+		},
+	}
+
+	plan, idealSpending, err := Plan(startDay, endDay, incomes, expenses)
+	assert.Equal(t, nil, err)
+	friday, _ := time.Parse(Types.DateFormat, "2015.08.14")
+	assert.Equal(t, "Income: Philz", plan[friday][0].Memo)
+	assert.Equal(t, money.New(279.), plan[friday][0].Delta)
+
+	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts[Types.Savings])
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+}
+
+func TestConservativePlanning(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+			Range:    &Types.Range{Min: money.New(400.), Max: money.New(600.)},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.New(400.),
+			Name:     "Rent",
+			Schedule: Types.Schedule{Period: Types.Monthly, Date: 28},
+		},
+	}
+
+	_, expectedIdeal, err := PlanWithOptions(startDay, endDay, incomes, expenses, Options{})
+	assert.Equal(t, nil, err)
+	plan, ideals, err := PlanWithIdeals(startDay, endDay, incomes, expenses, Options{Planning: PlanConservative})
+	assert.Equal(t, nil, err)
+	idealSpending := ideals.For(PlanConservative)
+	assert.Equal(t, ideals.Min, idealSpending)
+	assert.Equal(t, expectedIdeal, ideals.Expected)
+	assert.True(t, ideals.Expected.GreaterThan(ideals.Min))
+	assert.True(t, ideals.Max.GreaterThan(ideals.Expected))
+	// Each of the two paychecks is 100.00 from expected either way.
+	assert.InDelta(t, 200./30., ideals.Max.Subtract(ideals.Expected).Float(), 0.01)
+	assert.InDelta(t, 200./30., ideals.Expected.Subtract(ideals.Min).Float(), 0.01)
+
+	payday, _ := time.Parse(Types.DateFormat, "2015.08.15")
+	assert.Equal(t, money.New(500.), plan[payday][0].Delta)
+	assert.Equal(t, "Transfer to Savings: Philz above minimum", plan[payday][1].Memo)
+
+	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts[Types.Checking])
+	assert.Equal(t, money.New(200.), accounts[Types.Savings])
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+}
+
+func TestPlanInLocalTime(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip("no time zone data:", err)
+	}
+	startDay := time.Date(2015, 3, 1, 0, 0, 0, 0, la)
+	endDay := time.Date(2015, 3, 31, 0, 0, 0, 0, la)
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+		Types.Income{
+			Amount:   money.New(175.),
+			Name:     "Mission Cliffs",
+			Schedule: Types.Schedule{Period: Types.BiWeekly, Weekday: time.Thursday, Location: time.UTC},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.New(400.),
+			Name:     "Rent",
+			Schedule: Types.Schedule{Period: Types.Monthly, Date: 28},
+		},
+		Types.Expense{
+			Amount:   money.New(40.),
+			Name:     "Crossfit",
+			Schedule: Types.Schedule{Period: Types.Weekly, Weekday: time.Tuesday},
+		},
+	}
+
+	plan, idealSpending, err := Plan(startDay, endDay, incomes, expenses)
+	assert.Equal(t, nil, err)
+	for date := range plan {
+		assert.Equal(t, la, date.Location())
+		assert.Equal(t, 0, date.Hour())
+	}
+
+	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts[Types.Checking])
+	assert.Equal(t, money.New(0.), accounts[Types.Savings])
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+
+	utcStart, _ := time.Parse(Types.DateFormat, "2015.03.01")
+	utcEnd, _ := time.Parse(Types.DateFormat, "2015.03.31")
+	options := Options{Location: la}
+	localPlan, _, err := PlanWithOptions(utcStart, utcEnd, incomes, expenses, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, len(plan), len(localPlan))
+	accounts, _, err = SimulateWithOptions(utcStart, utcEnd, localPlan, false, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts[Types.Checking])
+}
+
+func TestExchangeRatesInLocalTime(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	startDay := time.Date(2015, 8, 1, 0, 0, 0, 0, tokyo)
+	endDay := time.Date(2015, 8, 31, 0, 0, 0, 0, tokyo)
+
+	rates := money.NewRateTable()
+	rateDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	assert.Nil(t, rates.Set(money.GBP, money.USD, rateDay, "1.5"))
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.NewIn(500., money.GBP),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+	}
+
+	plan, _, err := PlanWithOptions(startDay, endDay, incomes, []Types.Expense{}, Options{Currency: money.USD, Rates: rates})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.NewIn(750., money.USD), plan[startDay][0].Delta)
+}
+
+func TestNamedAccounts(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	registry, err := Types.NewRegistry(
+		Types.AccountInfo{Name: "Everyday", Kind: Types.CheckingAccount},
+		Types.AccountInfo{Name: "Bills", Kind: Types.CheckingAccount},
+		Types.AccountInfo{Name: "Rainy Day", Kind: Types.SavingsAccount},
+	)
+	assert.Nil(t, err)
+	options := Options{Accounts: registry}
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+		Types.Income{
+			Amount:   money.New(175.),
+			Name:     "Mission Cliffs",
+			Schedule: Types.Schedule{Period: Types.BiWeekly, Weekday: time.Thursday},
+			Account:  "Bills",
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.New(400.),
+			Name:     "Rent",
+			Schedule: Types.Schedule{Period: Types.Monthly, Date: 28},
+			Account:  "Bills",
+		},
+		Types.Expense{
+			Amount:   money.New(40.),
+			Name:     "Crossfit",
+			Schedule: Types.Schedule{Period: Types.Weekly, Weekday: time.Tuesday},
+		},
+	}
+
+	plan, idealSpending, err := PlanWithOptions(startDay, endDay, incomes, expenses, options)
+	assert.Equal(t, nil, err)
+	rent, _ := time.Parse(Types.DateFormat, "2015.08.28")
+	for _, transaction := range plan[rent] {
+		switch transaction.Memo {
+		case "Expense: Rent":
+			assert.Equal(t, Types.Account("Bills"), transaction.From)
+		case "Transfer from Rainy Day for: Rent":
+			// Bills kept the 175.00 paid in on the 20th toward rent.
+			assert.Equal(t, money.New(225.), transaction.Delta)
+		}
+	}
+	sweeps := []money.Money{}
+	for _, day := range []string{"2015.08.06", "2015.08.20"} {
+		date, _ := time.Parse(Types.DateFormat, day)
+		for _, transaction := range plan[date] {
+			if transaction.Memo == "Transfer to Everyday: Mission Cliffs" {
+				sweeps = append(sweeps, transaction.Delta)
+			}
+		}
+	}
+	assert.Equal(t, []money.Money{money.New(-175.)}, sweeps)
+
+	accounts, avgSimulatedSpending, err := SimulateWithOptions(startDay, endDay, plan, false, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts["Everyday"])
+	assert.Equal(t, money.New(0.), accounts["Bills"])
+	assert.Equal(t, money.New(0.), accounts["Rainy Day"])
+	_, ok := accounts[Types.Checking]
+	assert.False(t, ok)
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+}
+
+func TestExpensePaidFromSavings(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.New(50.),
+			Name:     "Ins",
+			Schedule: Types.Schedule{Period: Types.Monthly, Date: 20},
+			Account:  Types.Savings,
+		},
+	}
+
+	plan, idealSpending, err := PlanWithOptions(startDay, endDay, incomes, expenses, Options{})
+	assert.Equal(t, nil, err)
+	for _, transactions := range plan {
+		for _, transaction := range transactions {
+			assert.NotEqual(t, transaction.From, transaction.To, transaction.Memo)
+		}
+	}
+
+	accounts, avgSimulatedSpending, err := Simulate(startDay, endDay, plan, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts[Types.Checking])
+	assert.Equal(t, money.New(0.), accounts[Types.Savings])
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+
+	// A transfer into the account it comes from is refused rather than
+	// counted twice.
+	plan[startDay] = append(plan[startDay], Types.Transaction{
+		Date:  startDay,
+		Delta: money.New(50.),
+		Memo:  "Transfer from Savings for: Ins",
+		From:  Types.Savings,
+		To:    Types.Savings,
+	})
+	_, _, err = Simulate(startDay, endDay, plan, false)
+	assert.Error(t, err)
+}
+
+func TestAccountErrors(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+			Account:  "Credit Union",
+		},
+	}
+	_, _, err := PlanWithOptions(startDay, endDay, incomes, []Types.Expense{}, Options{})
+	assert.Error(t, err)
+
+	noSavings, _ := Types.NewRegistry(Types.AccountInfo{Name: "Everyday", Kind: Types.CheckingAccount})
+	_, _, err = PlanWithOptions(startDay, endDay, []Types.Income{}, []Types.Expense{}, Options{Accounts: noSavings})
+	assert.Error(t, err)
+
+	euros, _ := Types.NewRegistry(
+		Types.AccountInfo{Name: "Everyday", Kind: Types.CheckingAccount, Currency: money.EUR},
+		Types.AccountInfo{Name: "Rainy Day", Kind: Types.SavingsAccount, Currency: money.USD, Opening: money.NewIn(100., money.USD)},
+	)
+	_, _, err = PlanWithOptions(startDay, endDay, []Types.Income{}, []Types.Expense{}, Options{Accounts: euros})
+	assert.ErrorIs(t, err, money.ErrNoRate)
+}
+
+func TestAccountCurrencies(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+	}
+
+	// Without a plan currency the plan is in the accounts' dollars.
+	registry, err := Types.NewRegistry(
+		Types.AccountInfo{Name: Types.Checking, Kind: Types.CheckingAccount, Currency: money.USD},
+		Types.AccountInfo{Name: Types.Savings, Kind: Types.SavingsAccount, Currency: money.USD, Opening: money.NewIn(100., money.USD)},
+	)
+	assert.Nil(t, err)
+	options := Options{Accounts: registry}
+	plan, _, err := PlanWithOptions(startDay, endDay, incomes, []Types.Expense{}, options)
+	assert.Equal(t, nil, err)
+	accounts, _, err := SimulateWithOptions(startDay, endDay, plan, false, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.NewIn(0., money.USD), accounts[Types.Checking])
+
+	// Savings kept in euros is converted into the plan's dollars.
+	rates := money.NewRateTable()
+	assert.Nil(t, rates.Set(money.EUR, money.USD, startDay, "1.10"))
+	registry, err = Types.NewRegistry(
+		Types.AccountInfo{Name: Types.Checking, Kind: Types.CheckingAccount},
+		Types.AccountInfo{Name: Types.Savings, Kind: Types.SavingsAccount, Currency: money.EUR, Opening: money.NewIn(100., money.EUR)},
+	)
+	assert.Nil(t, err)
+	options = Options{Currency: money.USD, Rates: rates, Accounts: registry}
+	plan, _, err = PlanWithOptions(startDay, endDay, incomes, []Types.Expense{}, options)
+	assert.Equal(t, nil, err)
+	accounts, _, err = SimulateWithOptions(startDay, endDay, plan, false, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.NewIn(0., money.USD), accounts[Types.Savings])
+}
+
+func TestOpeningBalances(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.Monthly, Date: 15},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.New(400.),
+			Name:     "Rent",
+			Schedule: Types.Schedule{Period: Types.Monthly, Date: 28},
+		},
+	}
+
+	broke, _, err := PlanWithOptions(startDay, endDay, incomes, expenses, Options{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(broke[startDay]))
+
+	registry := Types.DefaultRegistry()
+	assert.Nil(t, registry.SetOpening(Types.Checking, money.New(140.)))
+	assert.Nil(t, registry.SetOpening(Types.Savings, money.New(300.)))
+	options := Options{Accounts: registry}
+
+	plan, idealSpending, err := PlanWithOptions(startDay, endDay, incomes, expenses, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(18.), idealSpending)
+	assert.Equal(t, simulatedSpendingMemo, plan[startDay][0].Memo)
+
+	accounts, avgSimulatedSpending, err := SimulateWithOptions(startDay, endDay, plan, false, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts[Types.Checking])
+	assert.Equal(t, money.New(0.), accounts[Types.Savings])
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+
+	assert.Nil(t, registry.SetOpening(Types.Checking, money.New(-50.)))
+	_, _, err = PlanWithOptions(startDay, endDay, incomes, expenses, options)
+	assert.Error(t, err)
+}
+
+func TestOpeningBalancesArePrinted(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+
+	registry := Types.DefaultRegistry()
+	assert.Nil(t, registry.SetOpening(Types.Checking, money.New(140.)))
+	assert.Nil(t, registry.SetOpening(Types.Savings, money.New(300.)))
+
+	read, write, err := os.Pipe()
+	assert.Nil(t, err)
+	stdout := os.Stdout
+	os.Stdout = write
+	_, _, err = SimulateWithOptions(startDay, startDay, map[time.Time][]Types.Transaction{}, true, Options{Accounts: registry})
+	os.Stdout = stdout
+	write.Close()
+	assert.Equal(t, nil, err)
+
+	output, _ := io.ReadAll(read)
+	assert.Contains(t, string(output), "2015.08.01 | <Initial balances>                       |                 |    140.00 |    300.00\n")
+}
+
+func creditRegistry(t *testing.T, checking money.Money) *Types.Registry {
+	registry := Types.DefaultRegistry()
+	assert.Nil(t, registry.SetOpening(Types.Checking, checking))
+	assert.Nil(t, registry.Register(Types.AccountInfo{
+		Name: "Visa",
+		Kind: Types.CreditAccount,
+		Credit: &Types.CreditTerms{
+			Statement: Types.Schedule{Period: Types.Monthly, Date: 10},
+			DueDays:   21,
+			APR:       money.Percent(36),
+			DayCount:  money.Actual365,
+		},
+	}))
+	return registry
+}
+
+func TestSpendingOnCredit(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	incomes := []Types.Income{
+		Types.Income{
+			Amount:   money.New(500.),
+			Name:     "Philz",
+			Schedule: Types.Schedule{Period: Types.BiMonthly},
+		},
+	}
+
+	expenses := []Types.Expense{
+		Types.Expense{
+			Amount:   money.New(400.),
+			Name:     "Rent",
+			Schedule: Types.Schedule{Period: Types.Monthly, Date: 28},
+		},
+	}
+
+	options := Options{Accounts: creditRegistry(t, money.New(0.)), SpendFrom: "Visa"}
+	plan, idealSpending, err := PlanWithOptions(startDay, endDay, incomes, expenses, options)
+	assert.Equal(t, nil, err)
+
+	statement := money.New(0.)
+	for date, transactions := range plan {
+		for _, transaction := range transactions {
+			if transaction.Memo == simulatedSpendingMemo {
+				assert.Equal(t, Types.Account("Visa"), transaction.From)
+				if !date.After(startDay.AddDate(0, 0, 9)) {
+					statement = statement.Add(transaction.Delta)
+				}
+			}
+		}
+	}
+	payment := plan[endDay][len(plan[endDay])-1]
+	assert.Equal(t, "Expense: Visa payment", payment.Memo)
+	assert.Equal(t, Types.Checking, payment.From)
+	assert.Equal(t, Types.Account("Visa"), payment.To)
+	assert.Equal(t, statement, payment.Delta)
+
+	// Spending after the statement closed is still owed, and the allowances
+	// for it are still in checking.
+	accounts, avgSimulatedSpending, err := SimulateWithOptions(startDay, endDay, plan, false, options)
+	assert.Equal(t, nil, err)
+	assert.True(t, money.New(0.).GreaterThan(accounts["Visa"]))
+	assert.Equal(t, money.New(0.), accounts[Types.Checking].Add(accounts["Visa"]))
+	assert.Equal(t, money.New(0.), accounts[Types.Savings])
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+}
+
+func TestOpeningCardBalanceIsPaid(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.10.31")
+	firstDue, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	registry := creditRegistry(t, money.New(600.))
+	assert.Nil(t, registry.SetOpening("Visa", money.New(-300.)))
+	options := Options{Accounts: registry, SpendFrom: "Visa"}
+
+	plan, idealSpending, err := PlanWithOptions(startDay, endDay, []Types.Income{}, []Types.Expense{}, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(3.29), idealSpending)
+	paid := false
+	for _, transaction := range plan[firstDue] {
+		if transaction.Memo == "Expense: Visa opening balance" {
+			assert.Equal(t, money.New(-300.), transaction.Delta)
+			paid = true
+		}
+	}
+	assert.True(t, paid)
+
+	// With the opening balance paid on time no interest is charged, and what
+	// is left in checking pays for spending since the last statement.
+	accounts, avgSimulatedSpending, err := SimulateWithOptions(startDay, endDay, plan, false, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts[Types.Checking].Add(accounts["Visa"]))
+	assert.InDelta(t, avgSimulatedSpending.Float()/idealSpending.Float(), 1., 0.05)
+}
+
+func TestCreditInterest(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.09.10")
+	purchaseDay, _ := time.Parse(Types.DateFormat, "2015.08.03")
+	dueDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	ledger := func(payment float64) map[time.Time][]Types.Transaction {
+		return map[time.Time][]Types.Transaction{
+			purchaseDay: []Types.Transaction{
+				Types.Transaction{
+					Date:  purchaseDay,
+					Delta: money.New(-1000.),
+					Memo:  "Expense: Laptop",
+					From:  "Visa",
+					To:    Types.External,
+				},
+			},
+			dueDay: []Types.Transaction{
+				Types.Transaction{
+					Date:  dueDay,
+					Delta: money.New(-payment),
+					Memo:  "Expense: Visa payment",
+					From:  Types.Checking,
+					To:    "Visa",
+				},
+			},
+		}
+	}
+
+	options := Options{Accounts: creditRegistry(t, money.New(1000.))}
+	accounts, _, err := SimulateWithOptions(startDay, endDay, ledger(1000.), false, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(0.), accounts["Visa"])
+
+	// 600.00 is carried for the 11 days from the due date through the next
+	// statement: 600.00 * 36% * 11/365 = 6.51.
+	accounts, _, err = SimulateWithOptions(startDay, endDay, ledger(400.), false, options)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, money.New(-606.51), accounts["Visa"])
+	assert.Equal(t, money.New(600.), accounts[Types.Checking])
+}
+
+func TestSpendFromErrors(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	registry := creditRegistry(t, money.New(0.))
+	assert.Nil(t, registry.Register(Types.AccountInfo{Name: "Wallet", Kind: Types.CashAccount}))
+	for _, spendFrom := range []Types.Account{"Mastercard", "Wallet", Types.Savings} {
+		_, _, err := PlanWithOptions(startDay, endDay, nil, nil, Options{Accounts: registry, SpendFrom: spendFrom})
+		assert.Error(t, err)
+	}
+}
+
+func TestInvalidSchedule(t *testing.T) {
+	startDay, _ := time.Parse(Types.DateFormat, "2015.08.01")
+	endDay, _ := time.Parse(Types.DateFormat, "2015.08.31")
+
+	for _, rule := range []string{"FREQ=FORTNIGHTLY", "FREQ=WEEKLY;INTERVAL=3"} {
+		expenses := []Types.Expense{
+			Types.Expense{
+				Amount:   money.New(120.),
+				Name:     "Storage Unit",
+				Schedule: Types.Schedule{Period: Types.Recurring, RRule: rule},
+			},
+		}
+
+		_, _, err := PlanWithOptions(startDay, endDay, []Types.Income{}, expenses, Options{})
+		assert.Error(t, err, rule)
+	}
+}
@@ -0,0 +1,57 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrOverflow ...
+var ErrOverflow = errors.New("money: overflow")
+
+func (m Money) checkCurrency(n Money) (Currency, error) {
+	if !m.Compatible(n) {
+		return NoCurrency, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, n.currency)
+	}
+	return m.resultCurrency(n), nil
+}
+
+// AddChecked is Add, but reports overflow and currency mismatches as errors
+// instead of wrapping or panicking.
+func (m Money) AddChecked(n Money) (Money, error) {
+	c, err := m.checkCurrency(n)
+	if err != nil {
+		return Money{}, err
+	}
+	sum := m.units + n.units
+	if (m.units >= 0) == (n.units >= 0) && (sum >= 0) != (m.units >= 0) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrOverflow, m.decimal(), n.decimal())
+	}
+	return Money{sum, c}, nil
+}
+
+// SubtractChecked ...
+func (m Money) SubtractChecked(n Money) (Money, error) {
+	c, err := m.checkCurrency(n)
+	if err != nil {
+		return Money{}, err
+	}
+	difference := m.units - n.units
+	if (m.units >= 0) != (n.units >= 0) && (difference >= 0) != (m.units >= 0) {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrOverflow, m.decimal(), n.decimal())
+	}
+	return Money{difference, c}, nil
+}
+
+// MulChecked is MultiplyRounded, but reports a result that doesn't fit in
+// an int64 of minor units as an error.
+func (m Money) MulChecked(n float64, mode RoundingMode) (Money, error) {
+	factor, ok := floatFactor(n)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: cannot multiply by %v", ErrOverflow, n)
+	}
+	product := m.times(factor, mode)
+	if !product.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s * %v", ErrOverflow, m.decimal(), n)
+	}
+	return Money{product.Int64(), m.currency}, nil
+}
@@ -0,0 +1,62 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddChecked(t *testing.T) {
+	m, err := New(1.50).AddChecked(New(2.25))
+	assert.Nil(t, err)
+	assert.Equal(t, New(3.75), m)
+
+	_, err = FromMinorUnits(math.MaxInt64).AddChecked(FromMinorUnits(1))
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = FromMinorUnits(math.MinInt64).AddChecked(FromMinorUnits(-1))
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	m, err = FromMinorUnits(math.MaxInt64).AddChecked(FromMinorUnits(math.MinInt64))
+	assert.Nil(t, err)
+	assert.Equal(t, FromMinorUnits(-1), m)
+}
+
+func TestSubtractChecked(t *testing.T) {
+	m, err := New(1.50).SubtractChecked(New(2.25))
+	assert.Nil(t, err)
+	assert.Equal(t, New(-0.75), m)
+
+	_, err = FromMinorUnits(math.MinInt64).SubtractChecked(FromMinorUnits(1))
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = FromMinorUnits(0).SubtractChecked(FromMinorUnits(math.MinInt64))
+	assert.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestCheckedCurrencyMismatch(t *testing.T) {
+	_, err := NewIn(1., EUR).AddChecked(NewIn(1., USD))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = NewIn(1., EUR).SubtractChecked(NewIn(1., USD))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestMulChecked(t *testing.T) {
+	m, err := New(1.03).MulChecked(3.141592654, HalfEven)
+	assert.Nil(t, err)
+	assert.Equal(t, New(3.24), m)
+
+	_, err = FromMinorUnits(1<<62).MulChecked(2., Truncate)
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = New(1.).MulChecked(math.Inf(1), Truncate)
+	assert.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestMulCheckedIsExactAbove2To53(t *testing.T) {
+	m, err := FromMinorUnits(1<<53+1).MulChecked(3., Truncate)
+	assert.Nil(t, err)
+	assert.Equal(t, FromMinorUnits(3*(1<<53+1)), m)
+}
@@ -0,0 +1,110 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Currency is an ISO 4217 currency code. The zero value means the amount
+// hasn't been tagged with a currency.
+type Currency string
+
+// Currencies ...
+const (
+	NoCurrency Currency = ""
+	AUD        Currency = "AUD"
+	CAD        Currency = "CAD"
+	CHF        Currency = "CHF"
+	CNY        Currency = "CNY"
+	EUR        Currency = "EUR"
+	GBP        Currency = "GBP"
+	JPY        Currency = "JPY"
+	KRW        Currency = "KRW"
+	KWD        Currency = "KWD"
+	MXN        Currency = "MXN"
+	USD        Currency = "USD"
+)
+
+const defaultDigits = 2
+
+var minorUnitDigits = map[Currency]int{
+	AUD: 2,
+	CAD: 2,
+	CHF: 2,
+	CNY: 2,
+	EUR: 2,
+	GBP: 2,
+	JPY: 0,
+	KRW: 0,
+	KWD: 3,
+	MXN: 2,
+	USD: 2,
+}
+
+var symbols = map[Currency]string{
+	AUD: "A$",
+	CAD: "CA$",
+	CHF: "CHF",
+	CNY: "¥",
+	EUR: "€",
+	GBP: "£",
+	JPY: "¥",
+	KRW: "₩",
+	KWD: "KD",
+	MXN: "MX$",
+	USD: "$",
+}
+
+// ErrCurrencyMismatch ...
+var ErrCurrencyMismatch = errors.New("money: currency mismatch")
+
+// Digits is the number of minor-unit digits for the currency (2 for USD, 0
+// for JPY, 3 for KWD). Unknown and untagged currencies use 2.
+func (c Currency) Digits() int {
+	if digits, ok := minorUnitDigits[c]; ok {
+		return digits
+	}
+	return defaultDigits
+}
+
+// Symbol is the usual symbol for the currency, or its code if it doesn't
+// have one. Untagged money has no symbol.
+func (c Currency) Symbol() string {
+	if symbol, ok := symbols[c]; ok {
+		return symbol
+	}
+	return string(c)
+}
+
+func (c Currency) scale() int64 {
+	scale := int64(1)
+	for i := 0; i < c.Digits(); i++ {
+		scale *= 10
+	}
+	return scale
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
+
+// Compatible ...
+func (m Money) Compatible(n Money) bool {
+	return m.currency == n.currency || m.isUntaggedZero() || n.isUntaggedZero()
+}
+
+func (m Money) isUntaggedZero() bool {
+	return m.currency == NoCurrency && m.units == 0
+}
+
+// An untagged zero (the zero value, or New(0.)) is compatible with every
+// currency so that it can be used as a starting total.
+func (m Money) resultCurrency(n Money) Currency {
+	if !m.Compatible(n) {
+		panic(fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, n.currency))
+	}
+	if m.currency == NoCurrency {
+		return n.currency
+	}
+	return m.currency
+}
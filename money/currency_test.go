@@ -0,0 +1,66 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigits(t *testing.T) {
+	assert.Equal(t, 2, USD.Digits())
+	assert.Equal(t, 0, JPY.Digits())
+	assert.Equal(t, 3, KWD.Digits())
+	assert.Equal(t, 2, NoCurrency.Digits())
+}
+
+func TestStringWithCurrency(t *testing.T) {
+	assert.Equal(t, "42.34", NewIn(42.34, EUR).String())
+	assert.Equal(t, "4234", NewIn(4234., JPY).String())
+	assert.Equal(t, "1.250", NewIn(1.25, KWD).String())
+	assert.Equal(t, "(0.005)", NewIn(-0.005, KWD).String())
+}
+
+func TestFloatWithCurrency(t *testing.T) {
+	assert.Equal(t, 4234., NewIn(4234., JPY).Float())
+	assert.Equal(t, 1.25, NewIn(1.25, KWD).Float())
+}
+
+func TestAddSameCurrency(t *testing.T) {
+	o := NewIn(1.50, EUR).Add(NewIn(2.25, EUR))
+	assert.Equal(t, NewIn(3.75, EUR), o)
+	assert.Equal(t, EUR, o.Currency())
+}
+
+func TestAddToUntaggedZero(t *testing.T) {
+	o := New(0.).Add(NewIn(2.25, EUR))
+	assert.Equal(t, NewIn(2.25, EUR), o)
+
+	var total Money
+	assert.Equal(t, NewIn(100., JPY), total.Add(NewIn(100., JPY)))
+}
+
+func TestMismatchedCurrencyPanics(t *testing.T) {
+	assert.Panics(t, func() { NewIn(1., EUR).Add(NewIn(1., USD)) })
+	assert.Panics(t, func() { NewIn(1., EUR).Subtract(NewIn(1., USD)) })
+	assert.Panics(t, func() { NewIn(1., EUR).GreaterThan(NewIn(1., USD)) })
+	assert.Panics(t, func() { Max(NewIn(1., EUR), NewIn(1., USD)) })
+	assert.Panics(t, func() { Min(NewIn(1., EUR), NewIn(1., USD)) })
+	assert.Panics(t, func() { New(1.).Add(NewIn(1., USD)) })
+}
+
+func TestEqualAcrossCurrencies(t *testing.T) {
+	assert.False(t, NewIn(1., EUR).EqualTo(NewIn(1., USD)))
+	assert.True(t, NewIn(0., EUR).EqualTo(New(0.)))
+}
+
+func TestCompatible(t *testing.T) {
+	assert.True(t, NewIn(1., EUR).Compatible(NewIn(2., EUR)))
+	assert.True(t, NewIn(1., EUR).Compatible(Money{}))
+	assert.False(t, NewIn(1., EUR).Compatible(NewIn(1., GBP)))
+	assert.False(t, NewIn(1., EUR).Compatible(New(1.)))
+}
+
+func TestDivideKeepsCurrency(t *testing.T) {
+	parts := NewIn(100., JPY).Divide(3)
+	assert.Equal(t, []Money{NewIn(33., JPY), NewIn(33., JPY), NewIn(34., JPY)}, parts)
+}
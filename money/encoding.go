@@ -0,0 +1,122 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// MarshalText writes the exact amount, followed by the currency code when
+// the amount is tagged: "-1234.56" or "1234.56 EUR".
+func (m Money) MarshalText() ([]byte, error) {
+	if m.currency == NoCurrency {
+		return []byte(m.decimal()), nil
+	}
+	return []byte(m.decimal() + " " + string(m.currency)), nil
+}
+
+// UnmarshalText accepts anything Parse does. If m is already tagged with a
+// currency, the text is read in that currency.
+func (m *Money) UnmarshalText(text []byte) error {
+	return m.parse(string(text), m.currency)
+}
+
+// parse sets m to s read in currency c.
+func (m *Money) parse(s string, c Currency) error {
+	parsed, err := ParseIn(s, c)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// MarshalJSON writes the MarshalText form as a JSON string.
+func (m Money) MarshalJSON() ([]byte, error) {
+	text, _ := m.MarshalText()
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON accepts a string or a bare number. Bare numbers are read
+// from their literal text, so they are exact too. Unlike UnmarshalText, the
+// value doesn't take the currency m had before.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return m.parse(s, NoCurrency)
+	}
+	return m.parse(string(data), NoCurrency)
+}
+
+// Value stores the MarshalText form.
+func (m Money) Value() (driver.Value, error) {
+	text, _ := m.MarshalText()
+	return string(text), nil
+}
+
+// Scan reads text and numeric columns. Integers are whole currency units.
+// Each value is read on its own, so one Money can be reused across rows.
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = Money{}
+		return nil
+	case string:
+		return m.parse(v, NoCurrency)
+	case []byte:
+		return m.parse(string(v), NoCurrency)
+	case int64:
+		return m.parse(strconv.FormatInt(v, 10), NoCurrency)
+	case float64:
+		return m.parse(strconv.FormatFloat(v, 'f', -1, 64), NoCurrency)
+	default:
+		return fmt.Errorf("money: cannot scan %T into Money", src)
+	}
+}
+
+// MarshalText writes the rate as String does: "4.25%".
+func (r Rate) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText accepts anything ParseRate does.
+func (r *Rate) UnmarshalText(text []byte) error {
+	parsed, err := ParseRate(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// MarshalJSON writes the MarshalText form as a JSON string.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	text, _ := r.MarshalText()
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON accepts a string or a bare number, which is read as a
+// fraction like "0.0425".
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return r.UnmarshalText([]byte(s))
+	}
+	return r.UnmarshalText(data)
+}
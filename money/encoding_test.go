@@ -0,0 +1,160 @@
+package money
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ json.Marshaler           = Money{}
+	_ json.Unmarshaler         = &Money{}
+	_ encoding.TextMarshaler   = Money{}
+	_ encoding.TextUnmarshaler = &Money{}
+	_ driver.Valuer            = Money{}
+	_ sql.Scanner              = &Money{}
+	_ json.Marshaler           = Rate{}
+	_ json.Unmarshaler         = &Rate{}
+	_ encoding.TextMarshaler   = Rate{}
+	_ encoding.TextUnmarshaler = &Rate{}
+)
+
+func TestMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Rent   Money
+		Salary Money
+		Yen    Money
+	}{New(-1234.56), NewIn(2500., EUR), NewIn(1500., JPY)})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Rent":"-1234.56","Salary":"2500.00 EUR","Yen":"1500 JPY"}`, string(data))
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var v struct {
+		A Money
+		B Money
+		C Money
+		D Money
+	}
+	err := json.Unmarshal([]byte(`{"A":"-1234.56","B":"2500.00 EUR","C":42.34,"D":null}`), &v)
+	assert.Nil(t, err)
+	assert.Equal(t, FromMinorUnits(-123456), v.A)
+	assert.Equal(t, FromMinorUnitsIn(250000, EUR), v.B)
+	assert.Equal(t, FromMinorUnits(4234), v.C)
+	assert.Equal(t, Money{}, v.D)
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	var m Money
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"12.345"`), &m), ErrSyntax)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`1e3`), &m), ErrSyntax)
+	assert.Error(t, json.Unmarshal([]byte(`true`), &m))
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, m := range []Money{New(0.), New(0.29), New(-42.99), FromMinorUnitsIn(-5, KWD), FromMinorUnits(1 << 62)} {
+		data, err := json.Marshal(m)
+		assert.Nil(t, err)
+
+		var n Money
+		assert.Nil(t, json.Unmarshal(data, &n))
+		assert.Equal(t, m, n)
+	}
+}
+
+func TestText(t *testing.T) {
+	text, err := NewIn(12.5, GBP).MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "12.50 GBP", string(text))
+
+	m := FromMinorUnitsIn(0, JPY)
+	assert.Nil(t, m.UnmarshalText([]byte("1,500")))
+	assert.Equal(t, NewIn(1500., JPY), m)
+}
+
+func TestRateJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		APR       Rate
+		Inflation Rate
+	}{BasisPoints(425), Rate{1}})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"APR":"4.25%","Inflation":"0.0001%"}`, string(data))
+
+	var v struct {
+		A Rate
+		B Rate
+		C Rate
+	}
+	assert.Nil(t, json.Unmarshal([]byte(`{"A":"425bp","B":0.0425,"C":null}`), &v))
+	assert.Equal(t, BasisPoints(425), v.A)
+	assert.Equal(t, BasisPoints(425), v.B)
+	assert.Equal(t, Rate{}, v.C)
+
+	for _, r := range []Rate{Rate{}, Percent(15), BasisPoints(-50), Rate{41250}} {
+		data, err := json.Marshal(r)
+		assert.Nil(t, err)
+
+		var s Rate
+		assert.Nil(t, json.Unmarshal(data, &s))
+		assert.Equal(t, r, s)
+	}
+	var r Rate
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"4.25%%"`), &r), ErrSyntax)
+}
+
+func TestValue(t *testing.T) {
+	v, err := NewIn(-3.5, USD).Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "-3.50 USD", v)
+}
+
+func TestScan(t *testing.T) {
+	var m Money
+	assert.Nil(t, m.Scan("12.34 EUR"))
+	assert.Equal(t, NewIn(12.34, EUR), m)
+
+	m = Money{}
+	assert.Nil(t, m.Scan([]byte("-0.01")))
+	assert.Equal(t, FromMinorUnits(-1), m)
+
+	m = Money{}
+	assert.Nil(t, m.Scan(int64(42)))
+	assert.Equal(t, New(42.), m)
+
+	m = Money{}
+	assert.Nil(t, m.Scan(0.29))
+	assert.Equal(t, FromMinorUnits(29), m)
+
+	assert.Nil(t, m.Scan(nil))
+	assert.Equal(t, Money{}, m)
+
+	assert.Error(t, m.Scan(true))
+	assert.Error(t, m.Scan(0.001))
+}
+
+func TestScanReusesOneMoney(t *testing.T) {
+	var m Money
+	assert.Nil(t, m.Scan("5.00 EUR"))
+	assert.Equal(t, NewIn(5., EUR), m)
+	assert.Nil(t, m.Scan("5.00 USD"))
+	assert.Equal(t, NewIn(5., USD), m)
+	assert.Nil(t, m.Scan("5.00"))
+	assert.Equal(t, New(5.), m)
+
+	var n Money
+	for _, row := range []struct {
+		data string
+		want Money
+	}{
+		{`"5.00 EUR"`, NewIn(5., EUR)},
+		{`"5.00 USD"`, NewIn(5., USD)},
+		{`5.00`, New(5.)},
+	} {
+		assert.Nil(t, json.Unmarshal([]byte(row.data), &n))
+		assert.Equal(t, row.want, n)
+	}
+}
@@ -0,0 +1,118 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SignStyle ...
+type SignStyle int
+
+// SignStyles ...
+const (
+	Parentheses SignStyle = iota
+	MinusSign
+)
+
+// Formatter controls how Money is written out. The zero value formats the
+// same way as Money.String: "1234.56" and "(1234.56)".
+type Formatter struct {
+	DecimalMark    string
+	GroupSeparator string
+	Sign           SignStyle
+	Symbol         bool
+	SymbolAfter    bool
+	SymbolSpace    bool
+	// Width pads the result with spaces on the left to at least Width
+	// characters, or on the right if Width is negative.
+	Width int
+}
+
+var locales = map[string]Formatter{
+	"en-US": {DecimalMark: ".", GroupSeparator: ",", Sign: MinusSign, Symbol: true},
+	"en-GB": {DecimalMark: ".", GroupSeparator: ",", Sign: MinusSign, Symbol: true},
+	"en-CA": {DecimalMark: ".", GroupSeparator: ",", Sign: MinusSign, Symbol: true},
+	"en-AU": {DecimalMark: ".", GroupSeparator: ",", Sign: MinusSign, Symbol: true},
+	"ja-JP": {DecimalMark: ".", GroupSeparator: ",", Sign: MinusSign, Symbol: true},
+	"de-DE": {DecimalMark: ",", GroupSeparator: ".", Sign: MinusSign, Symbol: true, SymbolAfter: true, SymbolSpace: true},
+	"es-ES": {DecimalMark: ",", GroupSeparator: ".", Sign: MinusSign, Symbol: true, SymbolAfter: true, SymbolSpace: true},
+	"it-IT": {DecimalMark: ",", GroupSeparator: ".", Sign: MinusSign, Symbol: true, SymbolAfter: true, SymbolSpace: true},
+	"nl-NL": {DecimalMark: ",", GroupSeparator: ".", Sign: MinusSign, Symbol: true, SymbolSpace: true},
+	"fr-FR": {DecimalMark: ",", GroupSeparator: "\u202f", Sign: MinusSign, Symbol: true, SymbolAfter: true, SymbolSpace: true},
+	"de-CH": {DecimalMark: ".", GroupSeparator: "’", Sign: MinusSign, Symbol: true, SymbolSpace: true},
+}
+
+// LocaleFormatter returns the Formatter for a locale such as "de-DE". POSIX
+// names like "de_DE.UTF-8" are accepted too.
+func LocaleFormatter(locale string) (Formatter, error) {
+	name := strings.SplitN(locale, ".", 2)[0]
+	name = strings.Replace(name, "_", "-", 1)
+	if f, ok := locales[name]; ok {
+		return f, nil
+	}
+	return Formatter{}, fmt.Errorf("money: unknown locale %q", locale)
+}
+
+// Format ...
+func (f Formatter) Format(m Money) string {
+	decimalMark := f.DecimalMark
+	if decimalMark == "" {
+		decimalMark = "."
+	}
+
+	abs := m.Abs()
+	scale := abs.currency.scale()
+	s := f.group(fmt.Sprintf("%d", abs.units/scale))
+	if digits := abs.currency.Digits(); digits > 0 {
+		s = fmt.Sprintf("%s%s%0*d", s, decimalMark, digits, abs.units%scale)
+	}
+
+	if f.Symbol && m.currency != NoCurrency {
+		space := ""
+		if f.SymbolSpace {
+			space = " "
+		}
+		if f.SymbolAfter {
+			s = s + space + m.currency.Symbol()
+		} else {
+			s = m.currency.Symbol() + space + s
+		}
+	}
+
+	if m.units < 0 {
+		if f.Sign == MinusSign {
+			s = "-" + s
+		} else {
+			s = "(" + s + ")"
+		}
+	}
+
+	if pad := absInt(f.Width) - utf8.RuneCountInString(s); pad > 0 {
+		if f.Width < 0 {
+			return s + strings.Repeat(" ", pad)
+		}
+		return strings.Repeat(" ", pad) + s
+	}
+	return s
+}
+
+func (f Formatter) group(digits string) string {
+	if f.GroupSeparator == "" {
+		return digits
+	}
+	groups := []string{}
+	for len(digits) > 3 {
+		groups = append([]string{digits[len(digits)-3:]}, groups...)
+		digits = digits[:len(digits)-3]
+	}
+	groups = append([]string{digits}, groups...)
+	return strings.Join(groups, f.GroupSeparator)
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
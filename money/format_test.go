@@ -0,0 +1,66 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZeroFormatterMatchesString(t *testing.T) {
+	for _, m := range []Money{New(0.), New(1234.56), New(-42.99), NewIn(1500., JPY)} {
+		assert.Equal(t, m.String(), Formatter{}.Format(m))
+	}
+}
+
+func TestFormatGrouping(t *testing.T) {
+	f := Formatter{GroupSeparator: ","}
+	assert.Equal(t, "0.05", f.Format(New(0.05)))
+	assert.Equal(t, "999.00", f.Format(New(999.)))
+	assert.Equal(t, "1,000.00", f.Format(New(1000.)))
+	assert.Equal(t, "1,234,567.89", f.Format(New(1234567.89)))
+	assert.Equal(t, "(1,234.56)", f.Format(New(-1234.56)))
+}
+
+func TestFormatSignStyles(t *testing.T) {
+	assert.Equal(t, "(12.00)", Formatter{Sign: Parentheses}.Format(New(-12.)))
+	assert.Equal(t, "-12.00", Formatter{Sign: MinusSign}.Format(New(-12.)))
+	assert.Equal(t, "-$12.00", Formatter{Sign: MinusSign, Symbol: true}.Format(NewIn(-12., USD)))
+	assert.Equal(t, "($12.00)", Formatter{Symbol: true}.Format(NewIn(-12., USD)))
+}
+
+func TestFormatSymbols(t *testing.T) {
+	f := Formatter{Symbol: true}
+	assert.Equal(t, "£5.00", f.Format(NewIn(5., GBP)))
+	assert.Equal(t, "¥1500", f.Format(NewIn(1500., JPY)))
+	assert.Equal(t, "5.00", f.Format(New(5.)))
+
+	f = Formatter{Symbol: true, SymbolAfter: true, SymbolSpace: true}
+	assert.Equal(t, "5.00 €", f.Format(NewIn(5., EUR)))
+}
+
+func TestFormatWidth(t *testing.T) {
+	assert.Equal(t, "     5.00", Formatter{Width: 9}.Format(New(5.)))
+	assert.Equal(t, "5.00     ", Formatter{Width: -9}.Format(New(5.)))
+	assert.Equal(t, "   5,00 €", Formatter{Width: 9, DecimalMark: ",", Symbol: true, SymbolAfter: true, SymbolSpace: true}.Format(NewIn(5., EUR)))
+	assert.Equal(t, "12345.00", Formatter{Width: 3}.Format(New(12345.)))
+}
+
+func TestLocaleFormatter(t *testing.T) {
+	de, err := LocaleFormatter("de-DE")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.234,56 €", de.Format(NewIn(1234.56, EUR)))
+	assert.Equal(t, "-1.234,56 €", de.Format(NewIn(-1234.56, EUR)))
+
+	us, err := LocaleFormatter("en_US.UTF-8")
+	assert.Nil(t, err)
+	assert.Equal(t, "$1,234.56", us.Format(NewIn(1234.56, USD)))
+	assert.Equal(t, "-1,234.56", us.Format(New(-1234.56)))
+
+	fr, _ := LocaleFormatter("fr_FR")
+	assert.Equal(t, "1\u202f234,56 €", fr.Format(NewIn(1234.56, EUR)))
+
+	_, err = LocaleFormatter("xx-XX")
+	assert.Error(t, err)
+	_, err = LocaleFormatter("")
+	assert.Error(t, err)
+}
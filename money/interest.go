@@ -0,0 +1,129 @@
+package money
+
+import (
+	"math"
+	"math/big"
+	"time"
+)
+
+// DayCount is the convention used to turn a span of days into a fraction of
+// a year when accruing interest.
+type DayCount int
+
+// DayCounts ...
+const (
+	Actual365 DayCount = iota
+	Actual360
+	Thirty360
+)
+
+func (d DayCount) String() string {
+	switch d {
+	case Actual365:
+		return "Actual/365"
+	case Actual360:
+		return "Actual/360"
+	case Thirty360:
+		return "30/360"
+	default:
+		return "???"
+	}
+}
+
+// YearFraction ...
+func (d DayCount) YearFraction(from, to time.Time) *big.Rat {
+	switch d {
+	case Actual360:
+		return big.NewRat(actualDays(from, to), 360)
+	case Thirty360:
+		return big.NewRat(thirty360Days(from, to), 360)
+	default:
+		return big.NewRat(actualDays(from, to), 365)
+	}
+}
+
+func actualDays(from, to time.Time) int64 {
+	fromYear, fromMonth, fromDay := from.Date()
+	toYear, toMonth, toDay := to.Date()
+	start := time.Date(fromYear, fromMonth, fromDay, 0, 0, 0, 0, time.UTC)
+	end := time.Date(toYear, toMonth, toDay, 0, 0, 0, 0, time.UTC)
+	return int64(end.Sub(start).Hours() / 24)
+}
+
+// thirty360Days uses the US (bond basis) rules.
+func thirty360Days(from, to time.Time) int64 {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := to.Date()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	return int64(360*(y2-y1) + 30*(int(m2)-int(m1)) + (d2 - d1))
+}
+
+// APRFromAPY is the nominal annual rate that, compounded periodsPerYear
+// times, yields apy. The result is rounded to the nearest 0.0001%.
+func APRFromAPY(apy Rate, periodsPerYear int) Rate {
+	n := float64(periodsPerYear)
+	return rateFromFloat(n * (math.Pow(1+apy.Float(), 1/n) - 1))
+}
+
+// APYFromAPR ...
+func APYFromAPR(apr Rate, periodsPerYear int) Rate {
+	n := float64(periodsPerYear)
+	return rateFromFloat(math.Pow(1+apr.Float()/n, n) - 1)
+}
+
+func rateFromFloat(f float64) Rate {
+	return Rate{int64(math.Round(f * rateScale))}
+}
+
+// SimpleInterest is the interest on principal at apr between two dates.
+func SimpleInterest(principal Money, apr Rate, from, to time.Time, dayCount DayCount, mode RoundingMode) Money {
+	rate := apr.rat()
+	factor := new(big.Rat).Mul(rate, dayCount.YearFraction(from, to))
+	return Money{principal.times(factor, mode).Int64(), principal.currency}
+}
+
+// Compound is principal after periods compounding periods at apr, with
+// periodsPerYear periods in a year (12 for monthly, 365 for daily).
+func Compound(principal Money, apr Rate, periodsPerYear int, periods int, mode RoundingMode) Money {
+	rate := apr.rat()
+	growth := new(big.Rat).Add(big.NewRat(1, 1), rate.Quo(rate, big.NewRat(int64(periodsPerYear), 1)))
+	factor := big.NewRat(1, 1)
+	for i := 0; i < periods; i++ {
+		factor.Mul(factor, growth)
+	}
+	return Money{principal.times(factor, mode).Int64(), principal.currency}
+}
+
+// Accrual accumulates interest day by day, keeping fractions of a minor unit
+// until the interest is posted.
+type Accrual struct {
+	APR      Rate
+	DayCount DayCount
+	accrued  big.Rat
+	currency Currency
+}
+
+// Accrue adds the interest earned by balance between from and to.
+func (a *Accrual) Accrue(balance Money, from, to time.Time) {
+	rate := a.APR.rat()
+	interest := new(big.Rat).SetInt64(balance.units)
+	interest.Mul(interest, rate)
+	interest.Mul(interest, a.DayCount.YearFraction(from, to))
+	a.accrued.Add(&a.accrued, interest)
+	if balance.currency != NoCurrency {
+		a.currency = balance.currency
+	}
+}
+
+// Post returns the whole minor units accrued so far, rounded with mode, and
+// carries the leftover fraction into the next posting.
+func (a *Accrual) Post(mode RoundingMode) Money {
+	units := mode.round(&a.accrued)
+	a.accrued.Sub(&a.accrued, new(big.Rat).SetInt(units))
+	return Money{units.Int64(), a.currency}
+}
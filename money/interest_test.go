@@ -0,0 +1,75 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYearFraction(t *testing.T) {
+	cases := []struct {
+		dayCount DayCount
+		from     string
+		to       string
+		want     string
+	}{
+		{Actual365, "2015-08-01", "2015-09-01", "31/365"},
+		{Actual360, "2015-08-01", "2015-09-01", "31/360"},
+		{Thirty360, "2015-08-01", "2015-09-01", "1/12"},
+		{Thirty360, "2015-01-31", "2015-03-01", "31/360"},
+		{Thirty360, "2015-01-30", "2015-03-31", "1/6"},
+		{Thirty360, "2015-01-31", "2016-01-31", "1"},
+	}
+	for _, c := range cases {
+		got := c.dayCount.YearFraction(day(c.from), day(c.to))
+		assert.Equal(t, c.want, got.RatString(), "%s %s-%s", c.dayCount, c.from, c.to)
+	}
+}
+
+func TestAPRAndAPY(t *testing.T) {
+	assert.Equal(t, "5.0848%", APYFromAPR(rate("4.97%"), 12).String())
+	assert.Equal(t, "4.97%", APRFromAPY(APYFromAPR(rate("4.97%"), 12), 12).String())
+	assert.Equal(t, "4.8793%", APRFromAPY(rate("5%"), 365).String())
+}
+
+func rate(s string) Rate {
+	r, err := ParseRate(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestSimpleInterest(t *testing.T) {
+	interest := SimpleInterest(New(1000.), Percent(5), day("2015-01-01"), day("2016-01-01"), Actual365, HalfEven)
+	assert.Equal(t, New(50.), interest)
+
+	interest = SimpleInterest(New(1000.), Percent(5), day("2015-08-01"), day("2015-09-01"), Thirty360, HalfEven)
+	assert.Equal(t, New(4.17), interest)
+
+	interest = SimpleInterest(NewIn(1000., EUR), Percent(5), day("2015-08-01"), day("2015-09-01"), Actual360, Floor)
+	assert.Equal(t, NewIn(4.30, EUR), interest)
+}
+
+func TestCompound(t *testing.T) {
+	assert.Equal(t, New(1051.16), Compound(New(1000.), Percent(5), 12, 12, HalfEven))
+	assert.Equal(t, New(1000.), Compound(New(1000.), Percent(5), 12, 0, HalfEven))
+	assert.Equal(t, New(1628.89), Compound(New(1000.), Percent(5), 1, 10, HalfEven))
+}
+
+func TestAccrualCarriesFractions(t *testing.T) {
+	a := Accrual{APR: Percent(1), DayCount: Actual365}
+	total := New(0.)
+	for d := day("2015-01-01"); d.Before(day("2016-01-01")); d = d.AddDate(0, 0, 1) {
+		a.Accrue(New(100.), d, d.AddDate(0, 0, 1))
+		total = total.Add(a.Post(Truncate))
+	}
+	assert.Equal(t, New(1.), total)
+}
+
+func TestAccrualKeepsCurrency(t *testing.T) {
+	a := Accrual{APR: rate("3.65%"), DayCount: Actual365}
+	a.Accrue(NewIn(1000., GBP), day("2015-01-01"), day("2015-01-11"))
+	assert.Equal(t, NewIn(1., GBP), a.Post(HalfEven))
+	assert.Equal(t, NewIn(0., GBP), a.Post(HalfEven))
+}
@@ -7,34 +7,56 @@ import (
 
 // New ...
 func New(m float64) Money {
-	return Money{int64(m * 100)}
+	return NewIn(m, NoCurrency)
+}
+
+// NewIn ...
+func NewIn(m float64, c Currency) Money {
+	return Money{int64(math.Round(m * float64(c.scale()))), c}
 }
 
 // Money ...
 type Money struct {
-	pennies int64
+	units    int64
+	currency Currency
 }
 
 func (m Money) String() string {
-	if m.pennies < 0 {
-		return fmt.Sprintf("(%.2f)", math.Abs(float64(m.pennies)/100.))
+	return Formatter{}.Format(m)
+}
+
+// decimal is the exact amount with a leading minus sign when negative.
+func (m Money) decimal() string {
+	scale := m.currency.scale()
+	sign, units := "", m.units
+	if units < 0 {
+		sign, units = "-", -units
 	}
-	return fmt.Sprintf("%.2f", float64(m.pennies)/100.)
+	s := fmt.Sprintf("%s%d", sign, units/scale)
+	if digits := m.currency.Digits(); digits > 0 {
+		s = fmt.Sprintf("%s.%0*d", s, digits, units%scale)
+	}
+	return s
+}
+
+// Currency ...
+func (m Money) Currency() Currency {
+	return m.currency
 }
 
 // Add ...
 func (m Money) Add(n Money) Money {
-	return Money{m.pennies + n.pennies}
+	return Money{m.units + n.units, m.resultCurrency(n)}
 }
 
 // Subtract ...
 func (m Money) Subtract(n Money) Money {
-	return Money{m.pennies - n.pennies}
+	return Money{m.units - n.units, m.resultCurrency(n)}
 }
 
 // Multiply ...
 func (m Money) Multiply(n float64) Money {
-	return Money{int64(float64(m.pennies) * n)}
+	return m.MultiplyRounded(n, Truncate)
 }
 
 // Divide ...
@@ -42,25 +64,26 @@ func (m Money) Divide(n int64) []Money {
 	if n <= 0 {
 		return []Money{}
 	}
-	equalPart := m.pennies / n
-	remaining := m.pennies
+	equalPart := m.units / n
+	remaining := m.units
 	result := []Money{}
 	for i := int64(0); i < n-1; i++ {
-		result = append(result, Money{equalPart})
+		result = append(result, Money{equalPart, m.currency})
 		remaining -= equalPart
 	}
-	result = append(result, Money{remaining})
+	result = append(result, Money{remaining, m.currency})
 	return result
 }
 
 // GreaterThan ...
 func (m Money) GreaterThan(right Money) bool {
-	return m.pennies > right.pennies
+	m.resultCurrency(right)
+	return m.units > right.units
 }
 
 // EqualTo ...
 func (m Money) EqualTo(right Money) bool {
-	return m.pennies == right.pennies
+	return m.Compatible(right) && m.units == right.units
 }
 
 // Max ...
@@ -87,10 +110,13 @@ func Min(args ...Money) Money {
 
 // Abs ...
 func (m Money) Abs() Money {
-	return Money{int64(math.Abs(float64(m.pennies)))}
+	if m.units < 0 {
+		return Money{-m.units, m.currency}
+	}
+	return m
 }
 
 // Float ...
 func (m Money) Float() float64 {
-	return float64(m.pennies) / 100.
+	return float64(m.units) / float64(m.currency.scale())
 }
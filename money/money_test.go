@@ -14,9 +14,15 @@ func TestString(t *testing.T) {
 	assert.Equal(t, "123.45", m.String())
 }
 
-func TestTruncation(t *testing.T) {
+func TestRounding(t *testing.T) {
 	m := New(123.456)
-	assert.Equal(t, "123.45", m.String())
+	assert.Equal(t, "123.46", m.String())
+
+	m = New(0.29)
+	assert.Equal(t, "0.29", m.String())
+
+	m = New(-0.29)
+	assert.Equal(t, "(0.29)", m.String())
 }
 
 func TestNegative(t *testing.T) {
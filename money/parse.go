@@ -0,0 +1,141 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrSyntax ...
+var ErrSyntax = errors.New("money: invalid amount")
+
+// FromMinorUnits ...
+func FromMinorUnits(units int64) Money {
+	return Money{units, NoCurrency}
+}
+
+// FromMinorUnitsIn ...
+func FromMinorUnitsIn(units int64, c Currency) Money {
+	return Money{units, c}
+}
+
+// MinorUnits ...
+func (m Money) MinorUnits() int64 {
+	return m.units
+}
+
+// Parse reads an exact decimal amount such as "42.34", "-1,234.56", "(12.00)"
+// or "$5". A trailing or leading ISO 4217 code ("12.50 EUR") tags the result
+// with that currency.
+func Parse(s string) (Money, error) {
+	return parse(s, NoCurrency)
+}
+
+// ParseIn is Parse for amounts in the given currency. A code in the string
+// must match c.
+func ParseIn(s string, c Currency) (Money, error) {
+	return parse(s, c)
+}
+
+func parse(input string, c Currency) (Money, error) {
+	fail := func(reason string) (Money, error) {
+		return Money{}, fmt.Errorf("%w %q: %s", ErrSyntax, input, reason)
+	}
+
+	s := strings.TrimSpace(input)
+	if code, rest, ok := splitCurrencyCode(s); ok {
+		if c != NoCurrency && c != code {
+			return fail(fmt.Sprintf("expected %s, got %s", c, code))
+		}
+		c = code
+		s = rest
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "(") || strings.HasSuffix(s, ")") {
+		if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+			return fail("unbalanced parentheses")
+		}
+		negative = true
+		s = s[1 : len(s)-1]
+	} else if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	s = strings.TrimPrefix(s, "$")
+
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+		if frac == "" {
+			return fail("missing digits after decimal point")
+		}
+	}
+	if whole == "" {
+		return fail("missing digits before decimal point")
+	}
+	if strings.Contains(whole, ",") {
+		groups := strings.Split(whole, ",")
+		if len(groups[0]) > 3 {
+			return fail("misplaced thousands separator")
+		}
+		for _, group := range groups[1:] {
+			if len(group) != 3 {
+				return fail("misplaced thousands separator")
+			}
+		}
+		whole = strings.Join(groups, "")
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return fail("unexpected character")
+	}
+
+	digits := c.Digits()
+	if len(frac) > digits {
+		if strings.Trim(frac[digits:], "0") != "" {
+			return fail(fmt.Sprintf("more than %d decimal places", digits))
+		}
+		frac = frac[:digits]
+	}
+	frac += strings.Repeat("0", digits-len(frac))
+
+	units, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok || !units.IsInt64() {
+		return fail("out of range")
+	}
+	if negative {
+		units.Neg(units)
+	}
+	return Money{units.Int64(), c}, nil
+}
+
+func splitCurrencyCode(s string) (Currency, string, bool) {
+	if len(s) > 4 && isCurrencyCode(s[:3]) && s[3] == ' ' {
+		return Currency(s[:3]), strings.TrimSpace(s[4:]), true
+	}
+	if len(s) > 4 && isCurrencyCode(s[len(s)-3:]) && s[len(s)-4] == ' ' {
+		return Currency(s[len(s)-3:]), strings.TrimSpace(s[:len(s)-4]), true
+	}
+	return NoCurrency, s, false
+}
+
+func isCurrencyCode(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return len(s) == 3
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMinorUnits(t *testing.T) {
+	assert.Equal(t, "0.29", FromMinorUnits(29).String())
+	assert.Equal(t, int64(29), FromMinorUnits(29).MinorUnits())
+	assert.Equal(t, "1.005", FromMinorUnitsIn(1005, KWD).String())
+}
+
+func TestParse(t *testing.T) {
+	cases := map[string]int64{
+		"42.34":     4234,
+		"0.29":      29,
+		"5":         500,
+		"$5":        500,
+		"-$5.5":     -550,
+		"-1,234.56": -123456,
+		"1,234,567": 123456700,
+		"(12.00)":   -1200,
+		"($12.00)":  -1200,
+		"+3.10":     310,
+		" 7.1 ":     710,
+		"1.2300":    123,
+	}
+	for input, units := range cases {
+		m, err := Parse(input)
+		assert.Nil(t, err, input)
+		assert.Equal(t, FromMinorUnits(units), m, input)
+	}
+}
+
+func TestParseRoundTripsString(t *testing.T) {
+	for _, m := range []Money{New(0.), New(123.45), New(-42.99), FromMinorUnitsIn(-5, KWD)} {
+		parsed, err := ParseIn(m.String(), m.Currency())
+		assert.Nil(t, err)
+		assert.Equal(t, m, parsed)
+	}
+}
+
+func TestParseCurrencyCode(t *testing.T) {
+	m, err := Parse("12.50 EUR")
+	assert.Nil(t, err)
+	assert.Equal(t, FromMinorUnitsIn(1250, EUR), m)
+
+	m, err = Parse("JPY 1,500")
+	assert.Nil(t, err)
+	assert.Equal(t, FromMinorUnitsIn(1500, JPY), m)
+
+	m, err = ParseIn("1.005", KWD)
+	assert.Nil(t, err)
+	assert.Equal(t, FromMinorUnitsIn(1005, KWD), m)
+
+	_, err = ParseIn("12.50 EUR", USD)
+	assert.ErrorIs(t, err, ErrSyntax)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"abc",
+		"1.234",
+		"1.",
+		".5",
+		"1,23.00",
+		"1234,567",
+		"(12.00",
+		"-(12.00)",
+		"--5",
+		"1 2",
+		"5 eur",
+		"99999999999999999999",
+	} {
+		_, err := Parse(input)
+		assert.ErrorIs(t, err, ErrSyntax, input)
+	}
+
+	_, err := ParseIn("5.5", JPY)
+	assert.ErrorIs(t, err, ErrSyntax)
+}
@@ -0,0 +1,118 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const rateScale = 1000000
+
+// Rate is an exact percentage, such as an interest rate or a share of a
+// paycheck, stored in hundredths of a basis point (0.0001%).
+type Rate struct {
+	millionths int64
+}
+
+// BasisPoints ...
+func BasisPoints(bp int64) Rate {
+	return Rate{bp * 100}
+}
+
+// Percent ...
+func Percent(p int64) Rate {
+	return Rate{p * 10000}
+}
+
+// ParseRate reads "4.25%", "425bp" or a plain fraction such as "0.0425".
+func ParseRate(s string) (Rate, error) {
+	input := s
+	s = strings.TrimSpace(s)
+	scale := int64(rateScale)
+	switch {
+	case strings.HasSuffix(s, "%"):
+		s, scale = s[:len(s)-1], rateScale/100
+	case strings.HasSuffix(s, "bps"):
+		s, scale = s[:len(s)-3], rateScale/10000
+	case strings.HasSuffix(s, "bp"):
+		s, scale = s[:len(s)-2], rateScale/10000
+	}
+	s = strings.TrimSpace(s)
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || strings.Trim(s, "+-.0123456789") != "" {
+		return Rate{}, fmt.Errorf("%w %q: not a rate", ErrSyntax, input)
+	}
+	r.Mul(r, big.NewRat(scale, 1))
+	if !r.IsInt() {
+		return Rate{}, fmt.Errorf("%w %q: finer than 0.0001%%", ErrSyntax, input)
+	}
+	if !r.Num().IsInt64() {
+		return Rate{}, fmt.Errorf("%w %q: out of range", ErrSyntax, input)
+	}
+	return Rate{r.Num().Int64()}, nil
+}
+
+// RateOf is part as a share of whole, rounded to the nearest 0.0001%.
+func RateOf(part, whole Money) (Rate, error) {
+	if _, err := part.checkCurrency(whole); err != nil {
+		return Rate{}, err
+	}
+	if whole.units == 0 {
+		return Rate{}, fmt.Errorf("money: rate of %s to zero", part.decimal())
+	}
+	r := big.NewRat(part.units, whole.units)
+	return Rate{HalfEven.round(r.Mul(r, big.NewRat(rateScale, 1))).Int64()}, nil
+}
+
+// BasisPoints returns the rate in whole basis points, truncating any finer
+// precision.
+func (r Rate) BasisPoints() int64 {
+	return r.millionths / 100
+}
+
+// Add ...
+func (r Rate) Add(s Rate) Rate {
+	return Rate{r.millionths + s.millionths}
+}
+
+// Abs ...
+func (r Rate) Abs() Rate {
+	if r.millionths < 0 {
+		return Rate{-r.millionths}
+	}
+	return r
+}
+
+// IsZero ...
+func (r Rate) IsZero() bool {
+	return r.millionths == 0
+}
+
+func (r Rate) rat() *big.Rat {
+	return big.NewRat(r.millionths, rateScale)
+}
+
+// Float is only meant for functions, like APYFromAPR, that need
+// fractional powers.
+func (r Rate) Float() float64 {
+	return float64(r.millionths) / rateScale
+}
+
+func (r Rate) String() string {
+	s := r.StringFixed(4)
+	s = strings.TrimRight(strings.TrimSuffix(s, "%"), "0")
+	return strings.TrimSuffix(s, ".") + "%"
+}
+
+// StringFixed writes the rate as a percentage with exactly decimals places,
+// rounding half away from zero.
+func (r Rate) StringFixed(decimals int) string {
+	percent := r.rat()
+	return percent.Mul(percent, big.NewRat(100, 1)).FloatString(decimals) + "%"
+}
+
+// ApplyRate is m times r, rounded to a whole minor unit using mode.
+func (m Money) ApplyRate(r Rate, mode RoundingMode) Money {
+	return Money{m.times(r.rat(), mode).Int64(), m.currency}
+}
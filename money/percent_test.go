@@ -0,0 +1,82 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRate(t *testing.T) {
+	cases := map[string]Rate{
+		"4.25%":    BasisPoints(425),
+		" 15 % ":   Percent(15),
+		"425bp":    BasisPoints(425),
+		"12.5 bps": Rate{1250},
+		"0.0425":   BasisPoints(425),
+		"1":        Percent(100),
+		"-0.5%":    BasisPoints(-50),
+		"4.125%":   Rate{41250},
+		"0.0001%":  Rate{1},
+	}
+	for input, want := range cases {
+		r, err := ParseRate(input)
+		assert.Nil(t, err, input)
+		assert.Equal(t, want, r, input)
+	}
+}
+
+func TestParseRateErrors(t *testing.T) {
+	for _, input := range []string{"", "%", "abc", "1/2", "1e2%", "0x10", "0.00001%", "4.25%%"} {
+		_, err := ParseRate(input)
+		assert.ErrorIs(t, err, ErrSyntax, input)
+	}
+}
+
+func TestRateString(t *testing.T) {
+	assert.Equal(t, "4.25%", BasisPoints(425).String())
+	assert.Equal(t, "15%", Percent(15).String())
+	assert.Equal(t, "0.0001%", Rate{1}.String())
+	assert.Equal(t, "-0.5%", BasisPoints(-50).String())
+	assert.Equal(t, "0%", Rate{}.String())
+	assert.Equal(t, "4.13%", Rate{41250}.StringFixed(2))
+}
+
+func TestRateRoundTrip(t *testing.T) {
+	for _, r := range []Rate{BasisPoints(425), Percent(-3), Rate{1}, Rate{123456789}} {
+		parsed, err := ParseRate(r.String())
+		assert.Nil(t, err)
+		assert.Equal(t, r, parsed)
+	}
+}
+
+func TestApplyRate(t *testing.T) {
+	assert.Equal(t, New(75.), New(500.).ApplyRate(Percent(15), HalfEven))
+	assert.Equal(t, New(0.04), New(1.).ApplyRate(BasisPoints(425), HalfEven))
+	assert.Equal(t, New(0.05), New(1.).ApplyRate(BasisPoints(425), Ceiling))
+	assert.Equal(t, New(0.04), New(1.).ApplyRate(BasisPoints(425), Floor))
+	assert.Equal(t, NewIn(13., JPY), NewIn(300., JPY).ApplyRate(BasisPoints(425), HalfUp))
+	assert.Equal(t, New(-4.25), New(-100.).ApplyRate(BasisPoints(425), Truncate))
+}
+
+func TestRateOf(t *testing.T) {
+	r, err := RateOf(New(24.11), New(24.92))
+	assert.Nil(t, err)
+	assert.Equal(t, "96.7496%", r.String())
+	assert.Equal(t, "96.75%", r.StringFixed(2))
+
+	r, _ = RateOf(New(1.), New(3.))
+	assert.Equal(t, Rate{333333}, r)
+
+	_, err = RateOf(New(1.), New(0.))
+	assert.Error(t, err)
+
+	_, err = RateOf(NewIn(1., EUR), NewIn(1., USD))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestBasisPointsAccessor(t *testing.T) {
+	assert.Equal(t, int64(425), BasisPoints(425).BasisPoints())
+	assert.Equal(t, int64(412), Rate{41250}.BasisPoints())
+	assert.Equal(t, BasisPoints(475), BasisPoints(425).Add(BasisPoints(50)))
+	assert.Equal(t, BasisPoints(50), BasisPoints(-50).Abs())
+}
@@ -0,0 +1,178 @@
+package money
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNoRate ...
+var ErrNoRate = errors.New("money: no exchange rate")
+
+var rateDateFormats = []string{"2006-01-02", "2006.01.02"}
+
+type currencyPair struct {
+	from Currency
+	to   Currency
+}
+
+type datedRate struct {
+	date time.Time
+	rate *big.Rat
+}
+
+// RateTable holds dated exchange rates. A rate applies from its date until
+// the next rate for the same pair. Dates are calendar days, whatever zone
+// they are in.
+type RateTable struct {
+	rates map[currencyPair][]datedRate
+}
+
+// NewRateTable ...
+func NewRateTable() *RateTable {
+	return &RateTable{rates: map[currencyPair][]datedRate{}}
+}
+
+// Set records that on date, one unit of from buys rate units of to. The rate
+// is a decimal string so that it is stored exactly.
+func (t *RateTable) Set(from, to Currency, date time.Time, rate string) error {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(rate))
+	if !ok || r.Sign() <= 0 {
+		return fmt.Errorf("money: invalid exchange rate %q for %s/%s", rate, from, to)
+	}
+	pair := currencyPair{from, to}
+	rates := append(t.rates[pair], datedRate{calendarDate(date), r})
+	sort.SliceStable(rates, func(i, j int) bool { return rates[i].date.Before(rates[j].date) })
+	t.rates[pair] = rates
+	return nil
+}
+
+// Rate returns the most recent rate from one currency to another on or before
+// date, inverting the opposite pair if only that one is known.
+func (t *RateTable) Rate(from, to Currency, date time.Time) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+	if t != nil {
+		if r := t.lookup(currencyPair{from, to}, date); r != nil {
+			return r, nil
+		}
+		if r := t.lookup(currencyPair{to, from}, date); r != nil {
+			return new(big.Rat).Inv(r), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s to %s on %s", ErrNoRate, from, to, date.Format(rateDateFormats[0]))
+}
+
+func (t *RateTable) lookup(pair currencyPair, date time.Time) *big.Rat {
+	date = calendarDate(date)
+	var found *big.Rat
+	for _, r := range t.rates[pair] {
+		if r.date.After(date) {
+			break
+		}
+		found = r.rate
+	}
+	return found
+}
+
+// calendarDate is date's year, month and day as midnight UTC.
+func calendarDate(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Convert converts m into the to currency at the rate in effect on date.
+// Untagged money is assumed to already be in the to currency.
+func (t *RateTable) Convert(m Money, to Currency, date time.Time) (Money, error) {
+	rate := big.NewRat(1, 1)
+	if m.currency != NoCurrency {
+		r, err := t.Rate(m.currency, to, date)
+		if err != nil {
+			return Money{}, err
+		}
+		rate = r
+	}
+	units := new(big.Rat).SetInt64(m.units)
+	units.Mul(units, rate)
+	units.Mul(units, new(big.Rat).SetFrac64(to.scale(), m.currency.scale()))
+	return Money{HalfUp.round(units).Int64(), to}, nil
+}
+
+// LoadRateTable reads a .json or .csv file of rates.
+func LoadRateTable(path string) (*RateTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ReadRatesJSON(f)
+	case ".csv":
+		return ReadRatesCSV(f)
+	default:
+		return nil, fmt.Errorf("money: unknown rate file type %q", path)
+	}
+}
+
+type jsonRate struct {
+	Date string      `json:"date"`
+	From Currency    `json:"from"`
+	To   Currency    `json:"to"`
+	Rate json.Number `json:"rate"`
+}
+
+// ReadRatesJSON reads an array of {"date", "from", "to", "rate"} objects.
+func ReadRatesJSON(r io.Reader) (*RateTable, error) {
+	var rows []jsonRate
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("money: reading rates: %w", err)
+	}
+	table := NewRateTable()
+	for _, row := range rows {
+		if err := table.setRow(row.Date, row.From, row.To, row.Rate.String()); err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
+
+// ReadRatesCSV reads date,from,to,rate rows, with an optional header row.
+func ReadRatesCSV(r io.Reader) (*RateTable, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("money: reading rates: %w", err)
+	}
+	table := NewRateTable()
+	for i, row := range rows {
+		if i == 0 && strings.EqualFold(row[0], "date") {
+			continue
+		}
+		if err := table.setRow(row[0], Currency(row[1]), Currency(row[2]), row[3]); err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
+
+func (t *RateTable) setRow(date string, from, to Currency, rate string) error {
+	for _, layout := range rateDateFormats {
+		if d, err := time.Parse(layout, strings.TrimSpace(date)); err == nil {
+			return t.Set(from, to, d, rate)
+		}
+	}
+	return fmt.Errorf("money: invalid rate date %q", date)
+}
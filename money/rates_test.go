@@ -0,0 +1,116 @@
+package money
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func day(s string) time.Time {
+	d, _ := time.Parse("2006-01-02", s)
+	return d
+}
+
+func TestConvert(t *testing.T) {
+	rates := NewRateTable()
+	assert.Nil(t, rates.Set(GBP, USD, day("2015-08-01"), "1.5612"))
+
+	m, err := rates.Convert(NewIn(100., GBP), USD, day("2015-08-03"))
+	assert.Nil(t, err)
+	assert.Equal(t, NewIn(156.12, USD), m)
+}
+
+func TestConvertUsesMostRecentRate(t *testing.T) {
+	rates := NewRateTable()
+	rates.Set(EUR, USD, day("2015-08-10"), "1.2")
+	rates.Set(EUR, USD, day("2015-08-01"), "1.1")
+
+	m, _ := rates.Convert(NewIn(10., EUR), USD, day("2015-08-09"))
+	assert.Equal(t, NewIn(11., USD), m)
+
+	m, _ = rates.Convert(NewIn(10., EUR), USD, day("2015-08-10"))
+	assert.Equal(t, NewIn(12., USD), m)
+
+	_, err := rates.Convert(NewIn(10., EUR), USD, day("2015-07-31"))
+	assert.ErrorIs(t, err, ErrNoRate)
+}
+
+func TestConvertComparesCalendarDates(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	rates := NewRateTable()
+	rates.Set(GBP, USD, day("2015-08-01"), "1.5")
+	rates.Set(GBP, USD, time.Date(2015, 8, 10, 0, 0, 0, 0, tokyo), "1.6")
+
+	m, err := rates.Convert(NewIn(10., GBP), USD, time.Date(2015, 8, 1, 0, 0, 0, 0, tokyo))
+	assert.Nil(t, err)
+	assert.Equal(t, NewIn(15., USD), m)
+
+	m, err = rates.Convert(NewIn(10., GBP), USD, day("2015-08-10"))
+	assert.Nil(t, err)
+	assert.Equal(t, NewIn(16., USD), m)
+}
+
+func TestConvertInverse(t *testing.T) {
+	rates := NewRateTable()
+	rates.Set(USD, JPY, day("2015-08-01"), "124.5")
+
+	m, err := rates.Convert(NewIn(1000., JPY), USD, day("2015-08-01"))
+	assert.Nil(t, err)
+	assert.Equal(t, Money{803, USD}, m)
+}
+
+func TestConvertAcrossPrecisions(t *testing.T) {
+	rates := NewRateTable()
+	rates.Set(KWD, USD, day("2015-08-01"), "3.3")
+
+	m, _ := rates.Convert(Money{1005, KWD}, USD, day("2015-08-01"))
+	assert.Equal(t, NewIn(3.32, USD), m)
+}
+
+func TestConvertSameCurrencyWithoutTable(t *testing.T) {
+	var rates *RateTable
+	m, err := rates.Convert(NewIn(5., USD), USD, day("2015-08-01"))
+	assert.Nil(t, err)
+	assert.Equal(t, NewIn(5., USD), m)
+
+	m, err = rates.Convert(New(5.), USD, day("2015-08-01"))
+	assert.Nil(t, err)
+	assert.Equal(t, NewIn(5., USD), m)
+
+	_, err = rates.Convert(NewIn(5., EUR), USD, day("2015-08-01"))
+	assert.ErrorIs(t, err, ErrNoRate)
+}
+
+func TestReadRatesJSON(t *testing.T) {
+	rates, err := ReadRatesJSON(strings.NewReader(`[
+		{"date": "2015-08-01", "from": "GBP", "to": "USD", "rate": 1.5},
+		{"date": "2015.08.15", "from": "GBP", "to": "USD", "rate": "1.25"}
+	]`))
+	assert.Nil(t, err)
+
+	m, _ := rates.Convert(NewIn(2., GBP), USD, day("2015-08-14"))
+	assert.Equal(t, NewIn(3., USD), m)
+	m, _ = rates.Convert(NewIn(2., GBP), USD, day("2015-08-15"))
+	assert.Equal(t, NewIn(2.5, USD), m)
+}
+
+func TestReadRatesCSV(t *testing.T) {
+	rates, err := ReadRatesCSV(strings.NewReader("date,from,to,rate\n2015-08-01, EUR, USD, 1.1\n"))
+	assert.Nil(t, err)
+
+	m, _ := rates.Convert(NewIn(10., EUR), USD, day("2015-08-01"))
+	assert.Equal(t, NewIn(11., USD), m)
+}
+
+func TestReadRatesRejectsBadRows(t *testing.T) {
+	_, err := ReadRatesCSV(strings.NewReader("2015-08-01,EUR,USD,abc\n"))
+	assert.Error(t, err)
+
+	_, err = ReadRatesCSV(strings.NewReader("08/01/2015,EUR,USD,1.1\n"))
+	assert.Error(t, err)
+
+	_, err = ReadRatesJSON(strings.NewReader(`[{"date": "2015-08-01", "from": "EUR", "to": "USD", "rate": -1}]`))
+	assert.Error(t, err)
+}
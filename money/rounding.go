@@ -0,0 +1,167 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// RoundingMode ...
+type RoundingMode int
+
+// RoundingModes ...
+const (
+	Truncate RoundingMode = iota
+	HalfEven
+	HalfUp
+	Floor
+	Ceiling
+)
+
+func (r RoundingMode) String() string {
+	switch r {
+	case Truncate:
+		return "Truncate"
+	case HalfEven:
+		return "HalfEven"
+	case HalfUp:
+		return "HalfUp"
+	case Floor:
+		return "Floor"
+	case Ceiling:
+		return "Ceiling"
+	default:
+		return "???"
+	}
+}
+
+// round rounds x to a whole number of minor units. HalfUp rounds ties away
+// from zero.
+func (r RoundingMode) round(x *big.Rat) *big.Int {
+	q, rem := new(big.Int).QuoRem(x.Num(), x.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return q
+	}
+	away := big.NewInt(int64(x.Sign()))
+	twice := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2))
+	half := twice.Cmp(x.Denom())
+
+	switch r {
+	case HalfEven:
+		if half > 0 || (half == 0 && q.Bit(0) == 1) {
+			q.Add(q, away)
+		}
+	case HalfUp:
+		if half >= 0 {
+			q.Add(q, away)
+		}
+	case Floor:
+		if x.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		}
+	case Ceiling:
+		if x.Sign() > 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+func (m Money) times(factor *big.Rat, mode RoundingMode) *big.Int {
+	x := new(big.Rat).SetInt64(m.units)
+	return mode.round(x.Mul(x, factor))
+}
+
+// floatFactor reads n from its shortest decimal form, so 1.1 is exactly
+// 11/10 rather than the nearest binary fraction.
+func floatFactor(n float64) (*big.Rat, bool) {
+	return new(big.Rat).SetString(strconv.FormatFloat(n, 'g', -1, 64))
+}
+
+// MultiplyRounded multiplies by n and rounds the result to a whole minor unit
+// using mode. Use MulChecked if the result could overflow.
+func (m Money) MultiplyRounded(n float64, mode RoundingMode) Money {
+	return Money{m.times(mustFactor(n), mode).Int64(), m.currency}
+}
+
+func mustFactor(n float64) *big.Rat {
+	factor, ok := floatFactor(n)
+	if !ok {
+		panic(fmt.Sprintf("money: cannot multiply by %v", n))
+	}
+	return factor
+}
+
+// Spread ...
+type Spread int
+
+// Spreads ...
+const (
+	SpreadFront Spread = iota
+	SpreadBack
+)
+
+// DivideEvenly splits m into n parts that differ by at most one minor unit,
+// handing the leftover units out one per part from the front or the back.
+func (m Money) DivideEvenly(n int64, spread Spread) []Money {
+	if n <= 0 {
+		return []Money{}
+	}
+	parts := make([]int64, n)
+	for i := range parts {
+		parts[i] = m.units / n
+	}
+	return m.distribute(parts, nil, m.units%n, spread)
+}
+
+// Allocate splits m in proportion to weights without losing or inventing a
+// minor unit. Leftover units go one per part from the front, skipping parts
+// with a weight of zero.
+func (m Money) Allocate(weights ...int) []Money {
+	total := int64(0)
+	for _, w := range weights {
+		if w < 0 {
+			return []Money{}
+		}
+		total += int64(w)
+	}
+	if total == 0 {
+		return []Money{}
+	}
+
+	parts := make([]int64, len(weights))
+	eligible := make([]bool, len(weights))
+	remainder := m.units
+	for i, w := range weights {
+		parts[i] = m.times(big.NewRat(int64(w), total), Truncate).Int64()
+		eligible[i] = w > 0
+		remainder -= parts[i]
+	}
+	return m.distribute(parts, eligible, remainder, SpreadFront)
+}
+
+// distribute hands remainder out one minor unit at a time. A nil eligible
+// means every part may receive a unit.
+func (m Money) distribute(parts []int64, eligible []bool, remainder int64, spread Spread) []Money {
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i++ {
+		j := i % len(parts)
+		if spread == SpreadBack {
+			j = len(parts) - 1 - j
+		}
+		if eligible != nil && !eligible[j] {
+			continue
+		}
+		parts[j] += step
+		remainder -= step
+	}
+
+	result := make([]Money, len(parts))
+	for i, units := range parts {
+		result[i] = Money{units, m.currency}
+	}
+	return result
+}
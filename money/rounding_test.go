@@ -0,0 +1,121 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiplyRounded(t *testing.T) {
+	m := New(1.03)
+	assert.Equal(t, New(3.23), m.MultiplyRounded(3.141592654, Truncate))
+	assert.Equal(t, New(3.24), m.MultiplyRounded(3.141592654, HalfUp))
+	assert.Equal(t, New(3.24), m.MultiplyRounded(3.141592654, HalfEven))
+	assert.Equal(t, New(3.23), m.MultiplyRounded(3.141592654, Floor))
+	assert.Equal(t, New(3.24), m.MultiplyRounded(3.141592654, Ceiling))
+}
+
+func TestRoundingTies(t *testing.T) {
+	cases := []struct {
+		units int64
+		mode  RoundingMode
+		want  int64
+	}{
+		{25, HalfEven, 2},
+		{35, HalfEven, 4},
+		{-25, HalfEven, -2},
+		{25, HalfUp, 3},
+		{-25, HalfUp, -3},
+		{25, Truncate, 2},
+		{-25, Truncate, -2},
+		{25, Floor, 2},
+		{-25, Floor, -3},
+		{25, Ceiling, 3},
+		{-25, Ceiling, -2},
+		{-21, Ceiling, -2},
+	}
+	for _, c := range cases {
+		got := FromMinorUnits(c.units).MultiplyRounded(0.1, c.mode)
+		assert.Equal(t, FromMinorUnits(c.want), got, "%d %s", c.units, c.mode)
+	}
+}
+
+func TestMultiplyIsExact(t *testing.T) {
+	m := FromMinorUnits(1 << 60)
+	assert.Equal(t, FromMinorUnits(1<<60), m.Multiply(1.))
+	assert.Equal(t, FromMinorUnits(-(1 << 60)), m.Multiply(-1.))
+	assert.Equal(t, FromMinorUnits(3), FromMinorUnits(3).Multiply(1.))
+}
+
+func TestMultiplyNaNPanics(t *testing.T) {
+	assert.Panics(t, func() { New(1.).MultiplyRounded(math.NaN(), HalfEven) })
+}
+
+func TestDivideEvenlyFront(t *testing.T) {
+	parts := New(1.00).DivideEvenly(3, SpreadFront)
+	assert.Equal(t, []Money{New(0.34), New(0.33), New(0.33)}, parts)
+
+	parts = New(0.05).DivideEvenly(3, SpreadFront)
+	assert.Equal(t, []Money{New(0.02), New(0.02), New(0.01)}, parts)
+}
+
+func TestDivideEvenlyBack(t *testing.T) {
+	parts := New(1.00).DivideEvenly(3, SpreadBack)
+	assert.Equal(t, []Money{New(0.33), New(0.33), New(0.34)}, parts)
+
+	parts = New(0.05).DivideEvenly(3, SpreadBack)
+	assert.Equal(t, []Money{New(0.01), New(0.02), New(0.02)}, parts)
+}
+
+func TestDivideEvenlyNegative(t *testing.T) {
+	parts := New(-1.00).DivideEvenly(3, SpreadFront)
+	assert.Equal(t, []Money{New(-0.34), New(-0.33), New(-0.33)}, parts)
+}
+
+func TestDivideEvenlyByZero(t *testing.T) {
+	assert.Equal(t, []Money{}, New(1.00).DivideEvenly(0, SpreadFront))
+	assert.Equal(t, []Money{}, New(1.00).DivideEvenly(-1, SpreadBack))
+}
+
+func TestAllocate(t *testing.T) {
+	parts := New(100.).Allocate(50, 30, 20)
+	assert.Equal(t, []Money{New(50.), New(30.), New(20.)}, parts)
+
+	parts = New(1000.01).Allocate(60, 40)
+	assert.Equal(t, []Money{New(600.01), New(400.)}, parts)
+
+	parts = New(0.05).Allocate(1, 1, 1)
+	assert.Equal(t, New(0.05).DivideEvenly(3, SpreadFront), parts)
+}
+
+func TestAllocateNeverLosesAPenny(t *testing.T) {
+	m := New(123.45)
+	parts := m.Allocate(7, 11, 13, 17)
+	total := New(0.)
+	for _, part := range parts {
+		total = total.Add(part)
+	}
+	assert.Equal(t, m, total)
+}
+
+func TestAllocateSkipsZeroWeights(t *testing.T) {
+	parts := New(0.03).Allocate(1, 0, 1)
+	assert.Equal(t, []Money{New(0.02), New(0.), New(0.01)}, parts)
+}
+
+func TestAllocateNegative(t *testing.T) {
+	parts := New(-0.05).Allocate(1, 1)
+	assert.Equal(t, []Money{New(-0.03), New(-0.02)}, parts)
+}
+
+func TestAllocateKeepsCurrency(t *testing.T) {
+	parts := NewIn(10., JPY).Allocate(1, 2)
+	assert.Equal(t, []Money{NewIn(4., JPY), NewIn(6., JPY)}, parts)
+}
+
+func TestAllocateInvalidWeights(t *testing.T) {
+	assert.Equal(t, []Money{}, New(1.).Allocate())
+	assert.Equal(t, []Money{}, New(1.).Allocate(0, 0))
+	assert.Equal(t, []Money{}, New(1.).Allocate(2, -1))
+}